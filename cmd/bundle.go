@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/bundle"
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/spf13/cobra"
+	kinddefaults "sigs.k8s.io/kind/pkg/apis/config/defaults"
+)
+
+var (
+	bundleOutput            string
+	bundleNodeImage         string
+	bundleKubernetesVersion string
+	bundleContainerEngine   string
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle [subcommand]",
+	Short: common.T("Comandos para bundles de imagens (uso offline)", "Comandos para bundles de imágenes (uso offline)"),
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: common.T("Salva as imagens do Girus em um arquivo tar", "Guarda las imágenes de Girus en un archivo tar"),
+	Long: common.T(`Salva em um único arquivo tar a imagem de nó do Kind, as imagens da plataforma
+(backend e frontend) e as imagens de todos os laboratórios embutidos.
+O arquivo pode ser usado com 'girus create cluster --bundle' em máquinas sem internet.`,
+		`Guarda en un único archivo tar la imagen de nodo de Kind, las imágenes de la plataforma
+(backend y frontend) y las imágenes de todos los laboratorios embebidos.
+El archivo puede usarse con 'girus create cluster --bundle' en máquinas sin internet.`),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cluster.ValidateOffline(common.ClusterProvider()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		images, err := bundle.ManifestImages()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		// A imagem de nó é salva sem digest, pois o 'save' não aceita referências por digest
+		topology := cluster.Topology{NodeImage: bundleNodeImage, KubernetesVersion: bundleKubernetesVersion}
+		if err := topology.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+		nodeImage := topology.Image()
+		if nodeImage == "" {
+			nodeImage = kinddefaults.Image
+		}
+		nodeImage = strings.SplitN(nodeImage, "@", 2)[0]
+		images = append([]string{nodeImage}, images...)
+
+		fmt.Println(headerColor(common.T("Preparando bundle de imagens...", "Preparando bundle de imágenes...")))
+		err = bundle.Create(bundleOutput, bundleContainerEngine, images, func(image string) {
+			fmt.Printf("   • %s\n", image)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		fmt.Printf(common.T("\n%s Bundle com %d imagens salvo em %s\n", "\n%s Bundle con %d imágenes guardado en %s\n"), green(common.T("SUCESSO:", "ÉXITO:")), len(images), magenta(bundleOutput))
+		fmt.Println(common.T("Para criar o cluster sem internet, execute:", "Para crear el cluster sin internet, ejecute:"))
+		fmt.Printf("   girus create cluster --bundle %s\n", bundleOutput)
+	},
+}
+
+func init() {
+	bundleCmd.AddCommand(bundleCreateCmd)
+
+	bundleCreateCmd.Flags().StringVar(&bundleOutput, "out", bundle.DefaultFile, common.T("Arquivo tar de saída", "Archivo tar de salida"))
+	bundleCreateCmd.Flags().StringVar(&bundleNodeImage, "node-image", "", common.T("Imagem de nó incluída no bundle (padrão: imagem padrão do Kind)", "Imagen de nodo incluida en el bundle (predeterminado: imagen predeterminada de Kind)"))
+	bundleCreateCmd.Flags().StringVar(&bundleKubernetesVersion, "kubernetes-version", "", common.T("Versão do Kubernetes da imagem de nó incluída (ex: v1.33.1)", "Versión de Kubernetes de la imagen de nodo incluida (ej: v1.33.1)"))
+	bundleCreateCmd.Flags().StringVarP(&bundleContainerEngine, "container-engine", "e", "docker", common.T("Engine de container (docker ou podman)", "Engine de contenedores (docker o podman)"))
+}
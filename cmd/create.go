@@ -1,35 +1,55 @@
 package cmd
 
 import (
-	"bufio"
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
+	"github.com/badtuxx/girus-cli/internal/bundle"
+	"github.com/badtuxx/girus-cli/internal/cluster"
 	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/doctor"
 	"github.com/badtuxx/girus-cli/internal/helpers"
 	"github.com/badtuxx/girus-cli/internal/k8s"
 	"github.com/badtuxx/girus-cli/internal/lab"
 	"github.com/badtuxx/girus-cli/internal/repo"
+	"github.com/badtuxx/girus-cli/internal/report"
 	"github.com/badtuxx/girus-cli/internal/templates"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 var (
 	deployFile      string
-	clusterName     string
 	verboseMode     bool
 	containerEngine string
 	labFile         string
 	skipPortForward bool
 	skipBrowser     bool
 	repoIndexURL    string
+	createDryRun    bool
+
+	// Opções de topologia do cluster local
+	workerNodes       int
+	nodeImage         string
+	kubernetesVersion string
+	portMappings      []string
+	extraMounts       []string
+
+	// Bundle de imagens para criação offline
+	bundleFile string
+
+	// Registry local com cache das imagens dos laboratórios
+	withRegistry bool
+
+	// Modo de acesso à aplicação a partir do host
+	exposeMode string
 )
 
 var createCmd = &cobra.Command{
@@ -43,7 +63,8 @@ var createCmd = &cobra.Command{
 var createClusterCmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Cria o cluster Girus",
-	Long: `Cria um cluster Kind com o nome "girus" e implanta todos os componentes necessários.
+	Long: `Cria um cluster local com o Kind, k3d ou minikube (--provider; por padrão um cluster Kind
+chamado "girus", ou o informado em --cluster) e implanta todos os componentes necessários.
 Por padrão, o deployment embutido no binário é utilizado.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Criar formatadores de cores
@@ -55,27 +76,34 @@ Por padrão, o deployment embutido no binário é utilizado.`,
 		magenta := color.New(color.FgMagenta).SprintFunc()
 		headerColor := color.New(color.FgCyan, color.Bold).SprintFunc()
 
+		// No modo --dry-run apenas exibir o que seria criado
+		if createDryRun {
+			if err := renderCluster(cmd, ""); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		// Exibir cabeçalho
 		fmt.Println(strings.Repeat("─", 80))
 		fmt.Println(headerColor(common.T("GIRUS CREATE", "GIRUS CREAR")))
 		fmt.Println(strings.Repeat("─", 80))
 
-		// Verificar se há atualização disponível para o CLI
-		fmt.Println(headerColor(common.T("Verificando atualizações...", "Verificando actualizaciones...")))
-
+		// Verificar se há atualização disponível para o CLI (exceto na criação offline)
+		var latestVersion string
+		var err error
 		currentVersion := common.Version
+		if bundleFile == "" {
+			fmt.Println(headerColor(common.T("Verificando atualizações...", "Verificando actualizaciones...")))
+			latestVersion, err = GetLatestGitHubVersion("badtuxx/girus-cli")
+		}
 
-		latestVersion, err := GetLatestGitHubVersion("badtuxx/girus-cli")
-
-		if err == nil && IsNewerVersion(latestVersion, currentVersion) {
+		if bundleFile == "" && err == nil && IsNewerVersion(latestVersion, currentVersion) {
 			fmt.Printf(common.T("%s versão %s disponível (atual: %s)\n", "%s versión %s disponible (actual: %s)\n"), yellow("AVISO:"), magenta(latestVersion), magenta(currentVersion))
-			fmt.Print(common.T("Deseja atualizar antes de criar o cluster? [S/n]: ", "¿Desea actualizar antes de crear el cluster? [S/n]: "))
 
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.ToLower(strings.TrimSpace(response))
-
-			if response == "" || response == "s" || response == "sim" || response == "y" || response == "yes" {
+			// Sem terminal a criação segue com a versão atual, sem trocar o binário no meio do processo
+			if helpers.Interactive() && helpers.Confirm(common.T("Deseja atualizar antes de criar o cluster?", "¿Desea actualizar antes de crear el cluster?"), true) {
 				// Criar comando de atualização
 				updateCmd := exec.Command("girus", "update")
 				updateCmd.Stdout = os.Stdout
@@ -92,693 +120,240 @@ Por padrão, o deployment embutido no binário é utilizado.`,
 			}
 		}
 
-		// Verificar se o containerEngine está instalado e funcionando
+		// Verificar os pré-requisitos com as mesmas verificações do 'girus doctor'
 		fmt.Println("\n" + headerColor(common.T("Verificando pré-requisitos...", "Verificando requisitos previos...")))
-		containerEngineCmd := exec.Command(containerEngine, "--version")
-		if err := containerEngineCmd.Run(); err != nil {
-			fmt.Printf(common.T("%s %s não encontrado ou não está em execução\n", "%s %s no encontrado o no está en ejecución\n"), red("ERRO:"), containerEngine)
-			fmt.Println(common.T("\nO "+containerEngine+" é necessário para criar um cluster Kind. Instruções de instalação:", "\n"+containerEngine+" es necesario para crear un cluster Kind. Instrucciones de instalación:"))
-
-			// Detectar o sistema operacional para instruções específicas
-			if runtime.GOOS == "darwin" && containerEngine == "docker" {
-				// macOS docker
-				fmt.Println("\nPara macOS, recomendamos usar Colima (alternativa leve ao Docker Desktop):")
-				fmt.Println("1. Instale o Homebrew caso não tenha:")
-				fmt.Println("   /bin/bash -c \"$(curl -fsSL https://raw.githubusercontent.com/Homebrew/install/HEAD/install.sh)\"")
-				fmt.Println("2. Instale o Colima e o Docker CLI:")
-				fmt.Println("   brew install colima docker")
-				fmt.Println("3. Inicie o Colima:")
-				fmt.Println("   colima start")
-				fmt.Println("\nAlternativamente, você pode instalar o Docker Desktop para macOS de:")
-				fmt.Println("https://www.docker.com/products/docker-desktop")
-			} else if runtime.GOOS == "linux" && containerEngine == "docker" {
-				// Linux docker
-				fmt.Println("\nPara Linux, use o script de instalação oficial:")
-				fmt.Println("   curl -fsSL https://get.docker.com | bash")
-				fmt.Println("\nApós a instalação, adicione seu usuário ao grupo docker para evitar usar sudo:")
-				fmt.Println("   sudo usermod -aG docker $USER")
-				fmt.Println("   newgrp docker")
-				fmt.Println("\nE inicie o serviço:")
-				fmt.Println("   sudo systemctl enable docker")
-				fmt.Println("   sudo systemctl start docker")
-			}
-			if runtime.GOOS == "darwin" && containerEngine == "podman" {
-				// macOS podman
-				fmt.Println("\nPara macOS, recomendamos Podman:")
-				fmt.Println("1. Instale o Homebrew caso não tenha:")
-				fmt.Println("   /bin/bash -c \"$(curl -fsSL https://raw.githubusercontent.com/Homebrew/install/HEAD/install.sh)\"")
-				fmt.Println("2. Instale o Podman")
-				fmt.Println("   brew install podman")
-				fmt.Println("3. Inicie o Podman:")
-				fmt.Println("   podman machine init")
-				fmt.Println("   podman machine start")
-			} else if runtime.GOOS == "linux" && containerEngine == "podman" {
-				// Linux podman
-				fmt.Println("\nPara Linux, use o script de instalação oficial:")
-				fmt.Println("   curl -fsSL https://get.docker.com | bash")
-				fmt.Println("\nE inicie o serviço:")
-				fmt.Println("   sudo systemctl enable podman")
-				fmt.Println("   sudo systemctl start podman")
-				fmt.Println("\nOpcional: Após a instalação, para utilizar podman, rootless evitando sudo:")
-				fmt.Println("   Siga as instruções do site oficial:")
-				fmt.Println("   https://github.com/containers/podman/blob/main/docs/tutorials/rootless_tutorial.md")
-			} else if containerEngine == "podman" {
-				// Windows ou outros sistemas
-				fmt.Println("\nVisite https://github.com/containers/podman/blob/main/docs/tutorials/podman-for-windows.md para instruções de instalação para seu sistema operacional")
-			} else {
-				// Windows ou outros sistemas
-				fmt.Println("\nVisite https://www.docker.com/products/docker-desktop para instruções de instalação para seu sistema operacional")
-			}
-
-			fmt.Println("\nApós instalar o " + containerEngine + " execute novamente este comando.")
+		checks := doctor.Run(doctor.Options{ContainerEngine: containerEngine, Provider: common.ClusterProvider(), KubectlOptional: true})
+		printChecks(checks)
+		if doctor.Failed(checks) {
+			fmt.Println("\n" + common.T("Corrija os itens com FALHA e execute novamente este comando.", "Corrija los elementos con FALLO y ejecute de nuevo este comando."))
 			os.Exit(1)
 		}
 
-		// Verificar se o serviço containerEngine está rodando
-		containerEngineInfoCmd := exec.Command(containerEngine, "info")
-		if err := containerEngineInfoCmd.Run(); err != nil {
-			fmt.Printf(common.T("%s O serviço %s não está em execução\n", "%s El servicio %s no está en ejecución\n"), red("ERRO:"), containerEngine)
-
-			if runtime.GOOS == "darwin" && containerEngine == "docker" {
-				fmt.Println("\nPara macOS com Colima:")
-				fmt.Println("   colima start")
-				fmt.Println("\nPara Docker Desktop:")
-				fmt.Println("   Inicie o aplicativo Docker Desktop")
-			} else if runtime.GOOS == "darwin" && containerEngine == "podman" {
-				fmt.Println("\nPara Podman:")
-				fmt.Println("   Inicie a machine com: podman machine start")
-			} else if runtime.GOOS == "linux" && containerEngine == "docker" {
-				fmt.Println("\nInicie o serviço Docker:")
-				fmt.Println("   sudo systemctl start docker")
-			} else if runtime.GOOS == "linux" && containerEngine == "podman" {
-				fmt.Println("\nInicie o serviço Podman:")
-				fmt.Println("   sudo systemctl start podman")
-			} else {
-				fmt.Println("\nInicie o serviço de containers apropriado para seu sistema.")
-			}
+		// Montar a topologia do cluster a partir da configuração e das flags
+		topology := clusterTopology(cmd)
 
-			fmt.Println("\nApós iniciar o " + containerEngine + ", execute novamente este comando.")
+		topology.Offline = bundleFile != ""
+		if err := topology.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
 			os.Exit(1)
 		}
+		if topology.Offline {
+			if err := cluster.ValidateOffline(common.ClusterProvider()); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+				os.Exit(1)
+			}
+		}
 
-		fmt.Printf("%s %s detectado e funcionando\n", green("ATIVO"), magenta(containerEngine))
+		// Importar o bundle antes de criar o cluster, para que nenhuma imagem precise ser baixada
+		var bundleImages []string
+		if bundleFile != "" {
+			bundleImages = loadBundle(bundleFile, &topology)
+		}
+
+		// Criar o gerenciador do provedor de cluster com a engine de container
+		// selecionada; as etapas reportadas pelo provedor viram o progresso da criação
+		r := newReporter(verboseMode)
+		createStep := fmt.Sprintf(common.T("Criando o cluster %s", "Creando el cluster %s"), clusterName)
+		clusterManager, err := cluster.NewProvider(common.ClusterProvider(), containerEngine, func(event cluster.StepEvent) {
+			r.Progress(createStep, event.Step, 0, 0)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+			os.Exit(1)
+		}
 
 		// Verificar silenciosamente se o cluster já existe
-		checkCmd := exec.Command("kind", "get", "clusters")
-		output, err := checkCmd.Output()
+		clusterExists, err := clusterManager.Exists(clusterName)
 
 		// Ignorar erros na checagem, apenas assumimos que não há clusters
-		if err == nil {
-			clusters := strings.Split(strings.TrimSpace(string(output)), "\n")
-
-			// Verificar se o cluster "girus" já existe
-			clusterExists := false
-			for _, cluster := range clusters {
-				if cluster == clusterName {
-					clusterExists = true
-					break
-				}
+		if err == nil && clusterExists {
+			fmt.Printf("%s %s\n", yellow(common.T("AVISO:", "AVISO:")), common.T("Cluster Girus já existe.", "El cluster Girus ya existe."))
+			if !helpers.Confirm(common.T("Deseja substituí-lo?", "¿Desea reemplazarlo?"), false) {
+				fmt.Println(common.T("Operação cancelada.", "Operación cancelada."))
+				return
 			}
 
-			if clusterExists {
-				fmt.Printf("%s %s\n", yellow(common.T("AVISO:", "AVISO:")), common.T("Cluster Girus já existe.", "El cluster Girus ya existe."))
-				fmt.Print(common.T("Deseja substituí-lo? [s/N]: ", "¿Desea reemplazarlo? [s/N]: "))
-
-				reader := bufio.NewReader(os.Stdin)
-				response, _ := reader.ReadString('\n')
-				response = strings.ToLower(strings.TrimSpace(response))
-
-				if response != "s" && response != "sim" && response != "y" && response != "yes" {
-					fmt.Println(common.T("Operação cancelada.", "Operación cancelada."))
-					return
+			// Excluir o cluster existente
+			deleteStep := common.T("Excluindo o cluster existente", "Eliminando el cluster existente")
+			if err := report.Run(r, deleteStep, func() error { return clusterManager.Delete(clusterName) }); err != nil {
+				if output := cluster.CommandOutput(err); output != "" {
+					fmt.Println("   Detalhes técnicos:", output)
 				}
+				fmt.Println("   Por favor, exclua manualmente com 'girus delete cluster' e tente novamente.")
+				os.Exit(1)
+			}
+		}
 
-				// Excluir o cluster existente
-				fmt.Println(headerColor("Excluindo cluster Girus existente..."))
-
-				deleteCmd := exec.Command("kind", "delete", "cluster", "--name", clusterName)
-				if verboseMode {
-					deleteCmd.Stdout = os.Stdout
-					deleteCmd.Stderr = os.Stderr
-					if err := deleteCmd.Run(); err != nil {
-						fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao excluir o cluster existente: %v\n", err)
-						fmt.Println("   Por favor, exclua manualmente com 'kind delete cluster --name girus' e tente novamente.")
-						os.Exit(1)
-					}
-				} else {
-					// Usar barra de progresso
-					barConfig := helpers.ProgressBarConfig{
-						Total:            100,
-						Description:      "Excluindo cluster existente...",
-						Width:            80,
-						Throttle:         65,
-						SpinnerType:      15,
-						RenderBlankState: true,
-						ShowBytes:        false,
-						SetPredictTime:   false,
-					}
-					bar := helpers.CreateProgressBar(barConfig)
-
-					var stderr bytes.Buffer
-					deleteCmd.Stderr = &stderr
-
-					// Iniciar o comando
-					err := deleteCmd.Start()
-					if err != nil {
-						fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao iniciar exclusão: %v\n", err)
-						os.Exit(1)
-					}
-
-					// Atualizar a barra de progresso
-					done := make(chan struct{})
-					go func() {
-						for {
-							select {
-							case <-done:
-								return
-							default:
-								bar.Add(1)
-								time.Sleep(100 * time.Millisecond)
-							}
-						}
-					}()
-
-					// Aguardar o final do comando
-					err = deleteCmd.Wait()
-					close(done)
-					bar.Finish()
-
-					if err != nil {
-						fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao excluir o cluster existente: %v\n", err)
-						fmt.Println("   Detalhes técnicos:", stderr.String())
-						fmt.Println("   Por favor, exclua manualmente com 'kind delete cluster --name girus' e tente novamente.")
-						os.Exit(1)
-					}
+		// Iniciar os registries locais, que sobrevivem à exclusão do cluster
+		if topology.Registry {
+			fmt.Println("\n" + headerColor(common.T("Iniciando registry local...", "Iniciando registry local...")))
+			if err := cluster.NewRegistry(containerEngine).Ensure(); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+				if output := cluster.CommandOutput(err); output != "" {
+					fmt.Println("   Detalhes técnicos:", output)
 				}
+				os.Exit(1)
+			}
+			fmt.Printf("%s %s %s\n", green("ATIVO"), common.T("Registry disponível em", "Registry disponible en"), magenta(cluster.RegistryHost))
+		}
 
-				fmt.Println("\n" + green(common.T("SUCESSO:", "ÉXITO:")) + " " + common.T("Cluster existente excluído com sucesso.", "Cluster existente eliminado con éxito."))
+		// Escolher as portas do host depois de excluir o cluster anterior: as
+		// ocupadas são trocadas pela próxima livre e as escolhidas ficam gravadas
+		// para os demais comandos e para a URL aberta no navegador
+		if topology.Expose != cluster.ExposeIngress {
+			if topology.Expose == cluster.ExposePortForward {
+				// O port-forward em execução para este cluster será substituído
+				k8s.StopPortForward()
+			}
+			ports, err := helpers.ChoosePorts(topology.HostPorts())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+				os.Exit(1)
+			}
+			warnChangedPorts(topology.HostPorts(), ports)
+			topology.Ports = ports
+			if err := common.SavePorts(ports); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", yellow("AVISO:"), err)
 			}
 		}
 
-		// Criar o cluster Kind
+		// Criar o cluster local
 		fmt.Println("\n" + headerColor(common.T("Criando cluster Girus...", "Creando cluster Girus...")))
+		if !topology.IsDefault() {
+			printTopology(topology)
+		}
 
-		if verboseMode {
-			// Executar normalmente mostrando o output
-			createClusterCmd := exec.Command("kind", "create", "cluster", "--name", clusterName)
-			createClusterCmd.Stdout = os.Stdout
-			createClusterCmd.Stderr = os.Stderr
-
-			if err := createClusterCmd.Run(); err != nil {
-				fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao criar o cluster Girus: %v\n", err)
+		r.Start(createStep)
+		if err := clusterManager.Create(clusterName, topology); err != nil {
+			r.Fail(createStep, err)
+
+			// Traduzir mensagens de erro comuns
+			errMsg := cluster.CommandOutput(err)
+
+			if errors.Is(err, cluster.ErrClusterExists) {
+				fmt.Println("   Erro: Já existe um cluster com o nome '" + clusterName + "' no sistema.")
+				fmt.Println("   Por favor, exclua-o primeiro com 'girus delete cluster'")
+			} else if strings.Contains(errMsg, "permission denied") {
+				fmt.Println("   Erro: Permissão negada. Verifique as permissões do " + containerEngine + ".")
+			} else if strings.Contains(errMsg, "Cannot connect to the Docker daemon") {
+				fmt.Println("   Erro: Não foi possível conectar ao serviço Docker.")
+				fmt.Println("   Verifique se o Docker está em execução com 'systemctl status docker'")
+			} else if errMsg != "" {
+				fmt.Println("   Detalhes técnicos:", errMsg)
+			} else {
 				fmt.Println("   Possíveis causas:")
 				fmt.Println("   • " + bold(containerEngine) + " não está em execução")
 				fmt.Println("   • Permissões insuficientes")
-				fmt.Println("   • Conflito com cluster existente")
-				os.Exit(1)
 			}
-		} else {
-			// Usar barra de progresso (padrão)
-			barConfig := helpers.ProgressBarConfig{
-				Total:            100,
-				Description:      common.T("Criando cluster...", "Creando cluster..."),
-				Width:            80,
-				Throttle:         65,
-				SpinnerType:      14,
-				RenderBlankState: true,
-				ShowBytes:        false,
-				SetPredictTime:   false,
-			}
-			bar := helpers.CreateProgressBar(barConfig)
-
-			// Executar comando sem mostrar saída
-			createClusterCmd := exec.Command("kind", "create", "cluster", "--name", clusterName)
-			var stderr bytes.Buffer
-			createClusterCmd.Stderr = &stderr
 
-			// Iniciar o comando
-			err := createClusterCmd.Start()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao iniciar o comando: %v\n", err)
-				os.Exit(1)
-			}
-
-			// Atualizar a barra de progresso enquanto o comando está em execução
-			done := make(chan struct{})
-			go func() {
-				for {
-					select {
-					case <-done:
-						return
-					default:
-						bar.Add(1)
-						time.Sleep(200 * time.Millisecond)
-					}
-				}
-			}()
+			os.Exit(1)
+		}
+		r.Done(createStep, "")
 
-			// Aguardar o final do comando
-			err = createClusterCmd.Wait()
-			close(done)
-			bar.Finish()
+		// Os demais comandos encontram o cluster pelo backend com que foi criado
+		if err := common.SaveClusterProvider(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", yellow("AVISO:"), err)
+		}
 
-			if err != nil {
-				fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao criar o cluster Girus: %v\n", err)
-
-				// Traduzir mensagens de erro comuns
-				errMsg := stderr.String()
-
-				if strings.Contains(errMsg, "node(s) already exist for a cluster with the name") {
-					fmt.Println("   Erro: Já existe um cluster com o nome 'girus' no sistema.")
-					fmt.Println("   Por favor, exclua-o primeiro com 'kind delete cluster --name girus'")
-				} else if strings.Contains(errMsg, "permission denied") {
-					fmt.Println("   Erro: Permissão negada. Verifique as permissões do " + containerEngine + ".")
-				} else if strings.Contains(errMsg, "Cannot connect to the Docker daemon") {
-					fmt.Println("   Erro: Não foi possível conectar ao serviço Docker.")
-					fmt.Println("   Verifique se o Docker está em execução com 'systemctl status docker'")
-				} else {
-					fmt.Println("   Detalhes técnicos:", errMsg)
+		// Carregar as imagens da plataforma e dos laboratórios nos nós do cluster
+		if len(bundleImages) > 0 {
+			loadStep := common.T("Carregando as imagens do bundle nos nós do cluster", "Cargando las imágenes del bundle en los nodos del cluster")
+			r.Start(loadStep)
+			if err := clusterManager.LoadImage(clusterName, bundleImages...); err != nil {
+				r.Fail(loadStep, err)
+				if output := cluster.CommandOutput(err); output != "" {
+					fmt.Println("   Detalhes técnicos:", output)
 				}
-
 				os.Exit(1)
 			}
+			r.Done(loadStep, fmt.Sprintf(common.T("%d imagens carregadas", "%d imágenes cargadas"), len(bundleImages)))
 		}
 
-		fmt.Println("\n" + green("SUCESSO:") + " Cluster Girus criado com sucesso!")
-
 		// Aplicar o manifesto de deployment do Girus
 		fmt.Println("\n" + headerColor("Implantando o Girus no cluster..."))
 
-		// Verificar se existe o arquivo girus-kind-deploy.yaml
-		deployYamlPath := "girus-kind-deploy.yaml"
-		foundDeployFile := false
-
-		// Verificar em diferentes locais possíveis
-		possiblePaths := []string{
-			deployYamlPath,                      // No diretório atual
-			filepath.Join("..", deployYamlPath), // Um nível acima
-			filepath.Join(os.Getenv("HOME"), "REPOS", "strigus", deployYamlPath), // Caminho comum
-		}
-
-		for _, path := range possiblePaths {
-			if _, err := os.Stat(path); err == nil {
-				deployFile = path
-				foundDeployFile = true
-				break
-			}
+		manifests, err := deployManifests()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+			os.Exit(1)
 		}
-
-		if foundDeployFile {
+		if deployFile != "" {
 			fmt.Printf("%s Usando arquivo de deployment: %s\n", cyan("INFO:"), magenta(deployFile))
+		}
 
-			// Aplicar arquivo de deployment completo (já contém o template do lab)
-			if verboseMode {
-				// Executar normalmente mostrando o output
-				applyCmd := exec.Command("kubectl", "apply", "-f", deployFile)
-				applyCmd.Stdout = os.Stdout
-				applyCmd.Stderr = os.Stderr
-
-				if err := applyCmd.Run(); err != nil {
-					fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao aplicar o manifesto do Girus: %v\n", err)
-					os.Exit(1)
-				}
-			} else {
-				// Usar barra de progresso
-				barConfig := helpers.ProgressBarConfig{
-					Total:            100,
-					Description:      "Implantando Girus...",
-					Width:            80,
-					Throttle:         65,
-					SpinnerType:      14,
-					RenderBlankState: true,
-					ShowBytes:        false,
-					SetPredictTime:   false,
-				}
-				bar := helpers.CreateProgressBar(barConfig)
-
-				// Executar comando sem mostrar saída
-				applyCmd := exec.Command("kubectl", "apply", "-f", deployFile)
-				var stderr bytes.Buffer
-				applyCmd.Stderr = &stderr
-
-				// Iniciar o comando
-				err := applyCmd.Start()
-				if err != nil {
-					fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao iniciar o comando: %v\n", err)
-					os.Exit(1)
-				}
-
-				// Atualizar a barra de progresso enquanto o comando está em execução
-				done := make(chan struct{})
-				go func() {
-					for {
-						select {
-						case <-done:
-							return
-						default:
-							bar.Add(1)
-							time.Sleep(100 * time.Millisecond)
-						}
-					}
-				}()
-
-				// Aguardar o final do comando
-				err = applyCmd.Wait()
-				close(done)
-				bar.Finish()
-
-				if err != nil {
-					fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao aplicar o manifesto do Girus: %v\n", err)
-					fmt.Println("   Detalhes técnicos:", stderr.String())
-					os.Exit(1)
-				}
-			}
-
-			fmt.Println("\n" + green(common.T("SUCESSO:", "ÉXITO:")) + " " + common.T("Infraestrutura e template de laboratório aplicados com sucesso!", "¡Infraestructura y plantilla de laboratorio aplicadas con éxito!"))
-		} else {
-			// Usar o deployment embutido como fallback
-			// fmt.Println("⚠️  Arquivo girus-kind-deploy.yaml não encontrado, usando deployment embutido.")
-
-			// Criar um arquivo temporário para o deployment principal
-			tempFile, err := os.CreateTemp("", "girus-deploy-*.yaml")
+		var objects []*unstructured.Unstructured
+		for _, manifest := range manifests {
+			parsed, err := k8s.ParseManifest(manifest.Content)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao criar arquivo temporário: %v\n", err)
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", red("ERRO:"), manifest.Name, err)
 				os.Exit(1)
 			}
-			defer os.Remove(tempFile.Name()) // Limpar o arquivo temporário ao finalizar
-
-			defaultDeployment, err := templates.GetManifest("defaultDeployment.yaml")
-			if err != nil {
-				fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao carregar o template: %v\n", err)
-				return
-			}
+			objects = append(objects, parsed...)
+		}
 
-			// Escrever o conteúdo no arquivo temporário
-			if _, err := tempFile.WriteString(string(defaultDeployment)); err != nil {
-				fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao escrever no arquivo temporário: %v\n", err)
+		// Publicar o Girus no host conforme o modo de acesso
+		if topology.Expose == cluster.ExposeIngress {
+			ingressStep := common.T("Instalando o ingress controller", "Instalando el ingress controller")
+			if err := report.Run(r, ingressStep, func() error {
+				return k8s.InstallIngressController(common.ClusterProvider(), 3*time.Minute)
+			}); err != nil {
 				os.Exit(1)
 			}
-			tempFile.Close()
-
-			// Aplicar o deployment principal
-			if verboseMode {
-				// Executar normalmente mostrando o output
-				applyCmd := exec.Command("kubectl", "apply", "-f", tempFile.Name())
-				applyCmd.Stdout = os.Stdout
-				applyCmd.Stderr = os.Stderr
-
-				if err := applyCmd.Run(); err != nil {
-					fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao aplicar o manifesto do Girus: %v\n", err)
-					os.Exit(1)
-				}
-			} else {
-				// Usar barra de progresso para o deploy (padrão)
-				barConfig := helpers.ProgressBarConfig{
-					Total:            100,
-					Description:      "Implantando infraestrutura...",
-					Width:            80,
-					Throttle:         65,
-					SpinnerType:      14,
-					RenderBlankState: true,
-					ShowBytes:        false,
-					SetPredictTime:   false,
-				}
-				bar := helpers.CreateProgressBar(barConfig)
-
-				// Executar comando sem mostrar saída
-				applyCmd := exec.Command("kubectl", "apply", "-f", tempFile.Name())
-				var stderr bytes.Buffer
-				applyCmd.Stderr = &stderr
-
-				// Iniciar o comando
-				err := applyCmd.Start()
-				if err != nil {
-					fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao iniciar o comando: %v\n", err)
-					os.Exit(1)
-				}
-
-				// Atualizar a barra de progresso enquanto o comando está em execução
-				done := make(chan struct{})
-				go func() {
-					for {
-						select {
-						case <-done:
-							return
-						default:
-							bar.Add(1)
-							time.Sleep(100 * time.Millisecond)
-						}
-					}
-				}()
-
-				// Aguardar o final do comando
-				err = applyCmd.Wait()
-				close(done)
-				bar.Finish()
-
-				if err != nil {
-					fmt.Fprintf(os.Stderr, red("ERRO:")+" Erro ao aplicar o manifesto do Girus: %v\n", err)
-					fmt.Println("   Detalhes técnicos:", stderr.String())
-					os.Exit(1)
-				}
-			}
-
-			fmt.Println("\n" + green(common.T("SUCESSO:", "ÉXITO:")) + " " + common.T("Infraestrutura básica aplicada com sucesso!", "¡Infraestructura básica aplicada con éxito!"))
-
-			// Agora vamos aplicar o template de laboratório que está embutido no binário
-			fmt.Println("\n" + headerColor(common.T("Aplicando templates de laboratório...", "Aplicando plantillas de laboratorio...")))
-
-			// Listar todos os arquivos YAML dentro de manifests/
-			manifestFiles, err := templates.ListManifests()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "%s Erro ao listar templates embutidos: %v\n", red("ERRO:"), err)
-				fmt.Println("   A infraestrutura básica foi aplicada, mas sem os templates de laboratório.")
-			} else if len(manifestFiles) == 0 {
-				fmt.Printf("   %s Nenhum template de laboratório embutido encontrado.\n", yellow("AVISO:"))
-			} else {
-				// Temos templates para aplicar
-				if verboseMode {
-					// Modo detalhado: Aplicar cada template individualmente mostrando logs
-					fmt.Printf("   Encontrados %d templates para aplicar:\n", len(manifestFiles))
-					allTemplatesApplied := true
-					for _, manifestName := range manifestFiles {
-						fmt.Printf("   - Aplicando %s...\n", manifestName)
-						// Ler o conteúdo do manifesto
-						manifestContent, err := templates.GetManifest(manifestName)
-						if err != nil {
-							fmt.Fprintf(os.Stderr, "     %s Erro ao carregar o template %s: %v\n", red("ERRO:"), manifestName, err)
-							allTemplatesApplied = false
-							continue
-						}
-
-						// Criar arquivo temporário
-						tempLabFile, err := os.CreateTemp("", "girus-template-*.yaml")
-						if err != nil {
-							fmt.Fprintf(os.Stderr, "     %s Erro ao criar arquivo temporário para %s: %v\n", red("ERRO:"), manifestName, err)
-							allTemplatesApplied = false
-							continue
-						}
-						tempPath := tempLabFile.Name() // Guardar o path antes de fechar
-
-						// Escrever e fechar arquivo temporário
-						if _, err := tempLabFile.Write(manifestContent); err != nil {
-							fmt.Fprintf(os.Stderr, "     %s Erro ao escrever template %s no arquivo temporário: %v\n", red("ERRO:"), manifestName, err)
-							tempLabFile.Close() // Fechar mesmo em caso de erro
-							os.Remove(tempPath) // Remover o temporário
-							allTemplatesApplied = false
-							continue
-						}
-						tempLabFile.Close()
-
-						// Aplicar com kubectl
-						applyCmd := exec.Command("kubectl", "apply", "-f", tempPath)
-						applyCmd.Stdout = os.Stdout
-						applyCmd.Stderr = os.Stderr
-						if err := applyCmd.Run(); err != nil {
-							fmt.Fprintf(os.Stderr, "     %s Erro ao aplicar o template %s: %v\n", red("ERRO:"), manifestName, err)
-							allTemplatesApplied = false
-						} else {
-							fmt.Printf("     %s Template %s aplicado com sucesso!\n", green("SUCESSO:"), manifestName)
-						}
-						os.Remove(tempPath) // Remover o temporário após o uso
-					}
-
-					if allTemplatesApplied {
-						fmt.Printf(common.T("%s Todos os templates de laboratório embutidos aplicados com sucesso!\n", "%s ¡Todas las plantillas de laboratorio embebidas aplicadas con éxito!\n"), green(common.T("SUCESSO:", "ÉXITO:")))
-					} else {
-						fmt.Printf(common.T("%s Alguns templates de laboratório não puderam ser aplicados.\n", "%s Algunas plantillas de laboratorio no se pudieron aplicar.\n"), yellow(common.T("AVISO:", "AVISO:")))
-					}
-
-				} else {
-					// Modo com barra de progresso: Aplicar cada template individualmente
-					barConfig := helpers.ProgressBarConfig{
-						Total:            len(manifestFiles),
-						Description:      common.T("Aplicando templates de laboratório...", "Aplicando plantillas de laboratorio..."),
-						Width:            80,
-						Throttle:         65,
-						SpinnerType:      14,
-						RenderBlankState: true,
-						ShowBytes:        false,
-						SetPredictTime:   false,
-					}
-					bar := helpers.CreateProgressBar(barConfig)
-
-					allSuccess := true
-					for _, manifestName := range manifestFiles {
-						// Ler o conteúdo do manifesto
-						manifestContent, err := templates.GetManifest(manifestName)
-						if err != nil {
-							bar.Add(1) // Incrementar a barra mesmo com erro
-							allSuccess = false
-							continue
-						}
-
-						// Criar arquivo temporário
-						tempLabFile, err := os.CreateTemp("", "girus-template-*.yaml")
-						if err != nil {
-							bar.Add(1) // Incrementar a barra mesmo com erro
-							allSuccess = false
-							continue
-						}
-						tempPath := tempLabFile.Name()
-
-						// Escrever e fechar arquivo temporário
-						if _, err := tempLabFile.Write(manifestContent); err != nil {
-							tempLabFile.Close()
-							os.Remove(tempPath)
-							bar.Add(1) // Incrementar a barra mesmo com erro
-							allSuccess = false
-							continue
-						}
-						tempLabFile.Close()
-
-						// Aplicar com kubectl
-						applyCmd := exec.Command("kubectl", "apply", "-f", tempPath)
-						var stderr bytes.Buffer
-						applyCmd.Stderr = &stderr
-						if err := applyCmd.Run(); err != nil {
-							os.Remove(tempPath)
-							bar.Add(1) // Incrementar a barra mesmo com erro
-							allSuccess = false
-							continue
-						}
-
-						os.Remove(tempPath)
-						bar.Add(1) // Incrementar a barra após sucesso
-					}
-					bar.Finish()
-
-					if allSuccess {
-						fmt.Println("\n" + green(common.T("SUCESSO:", "ÉXITO:")) + " " + common.T("Todos os templates de laboratório aplicados com sucesso!", "¡Todas las plantillas de laboratorio aplicadas con éxito!"))
-					} else {
-						fmt.Println("\n" + yellow(common.T("AVISO:", "AVISO:")) + " " + common.T("Alguns templates de laboratório não puderam ser aplicados. Use --verbose para detalhes.", "Algunas plantillas de laboratorio no se pudieron aplicar. Use --verbose para más detalles."))
-					}
-
-					// Verificação de diagnóstico para confirmar que os templates estão visíveis
-					fmt.Println("\n" + headerColor(common.T("Verificando templates de laboratório instalados:", "Verificando plantillas de laboratorio instaladas:")))
-					listLabsCmd := exec.Command("kubectl", "get", "configmap", "-n", "girus", "-l", "app=girus-lab-template", "-o", "custom-columns=NAME:.metadata.name")
-					var labsOutput bytes.Buffer
-					listLabsCmd.Stdout = &labsOutput
-					listLabsCmd.Stderr = &labsOutput
-
-					if err := listLabsCmd.Run(); err == nil {
-						labs := strings.Split(strings.TrimSpace(labsOutput.String()), "\n")
-						if len(labs) > 1 { // Primeira linha é o cabeçalho "NAME"
-							fmt.Println(common.T("   Templates encontrados:", "   Plantillas encontradas:"))
-							for i, lab := range labs {
-								if i > 0 { // Pular o cabeçalho
-									fmt.Printf("   %s %s\n", green("ATIVO"), strings.TrimSpace(lab))
-								}
-							}
-						} else {
-							fmt.Printf("   %s %s\n", yellow(common.T("AVISO:", "AVISO:")), common.T("Nenhum template de laboratório encontrado!", "¡Ninguna plantilla de laboratorio encontrada!"))
-						}
-					} else {
-						fmt.Printf("   %s %s\n", yellow(common.T("AVISO:", "AVISO:")), common.T("Não foi possível verificar os templates instalados", "No fue posible verificar las plantillas instaladas"))
-					}
-				}
-
-				// Reiniciar o backend para carregar os templates
-				fmt.Println("\n" + headerColor(common.T("Reiniciando o backend para carregar os templates...", "Reiniciando el backend para cargar las plantillas...")))
-				restartCmd := exec.Command("kubectl", "rollout", "restart", "deployment/girus-backend", "-n", "girus")
-				restartCmd.Run()
-
-				// Aguardar o reinício completar
-				fmt.Println(common.T("   Aguardando o reinício do backend completar...", "   Esperando a que el backend reinicie..."))
-				waitCmd := exec.Command("kubectl", "rollout", "status", "deployment/girus-backend", "-n", "girus", "--timeout=60s")
-				// Redirecionar saída para não exibir detalhes do rollout
-				var waitOutput bytes.Buffer
-				waitCmd.Stdout = &waitOutput
-				waitCmd.Stderr = &waitOutput
-
-				// Iniciar indicador de progresso simples
-				spinChars := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-				spinIdx := 0
-				done := make(chan struct{})
-				go func() {
-					for {
-						select {
-						case <-done:
-							return
-						default:
-							fmt.Printf("\r   %s Aguardando... ", spinChars[spinIdx])
-							spinIdx = (spinIdx + 1) % len(spinChars)
-							time.Sleep(100 * time.Millisecond)
-						}
-					}
-				}()
-
-				// Executar e aguardar
-				waitCmd.Run()
-				close(done)
-				fmt.Printf("\r   %s %s            \n", green(common.T("SUCESSO:", "ÉXITO:")), common.T("Backend reiniciado com sucesso!", "¡Backend reiniciado con éxito!"))
-
-				// Aguardar mais alguns segundos para o backend inicializar completamente
-				fmt.Println(common.T("   Aguardando inicialização completa...", "   Esperando a que la inicialización complete..."))
-				time.Sleep(5 * time.Second)
-			}
 		}
+		objects, err = k8s.ExposeObjects(objects, topology.Expose, common.ClusterProvider())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+			os.Exit(1)
+		}
+
+		applyGirusObjects(objects, r)
 
 		// Aguardar os pods do Girus ficarem prontos
-		if err := k8s.WaitForPodsReady("girus", 5*time.Minute); err != nil {
-			fmt.Fprintf(os.Stderr, "%s %v\n", yellow("AVISO:"), err)
+		if err := k8s.WaitForPodsReady("girus", 5*time.Minute, r); err != nil {
 			fmt.Println("Recomenda-se verificar o estado dos pods com 'kubectl get pods -n girus'")
-		} else {
-			fmt.Printf("%s Todos os componentes do Girus estão prontos e em execução!\n", green("SUCESSO:"))
 		}
 
 		fmt.Printf("%s Girus implantado com sucesso no cluster!\n", green("SUCESSO:"))
 
-		// Configurar port-forward automaticamente (a menos que --skip-port-forward tenha sido especificado)
-		if !skipPortForward {
+		accessURL := k8s.AccessURL(topology.Expose)
+		openBrowser := !skipBrowser
+
+		switch {
+		case topology.Expose != cluster.ExposePortForward:
+			// Nos modos nodeport e ingress o acesso é permanente, sem port-forward
+			fmt.Println("\n" + headerColor(common.T("Acesso aos serviços do Girus:", "Acceso a los servicios de Girus:")))
+			fmt.Println(bold("Backend:") + " " + k8s.BackendURL(topology.Expose) + "/api")
+			fmt.Println(bold("Frontend:") + " " + accessURL)
+		case !skipPortForward:
+			// Configurar port-forward automaticamente (a menos que --skip-port-forward tenha sido especificado)
 			fmt.Print("\n" + headerColor(common.T("Configurando acesso aos serviços do Girus...", "Configurando el acceso a los servicios de Girus...")) + " ")
 
-			if err := k8s.SetupPortForward("girus"); err != nil {
+			if err := k8s.SetupPortForward(); err != nil {
+				openBrowser = false
 				fmt.Printf("%s\n", yellow(common.T("AVISO:", "AVISO:")))
 				fmt.Printf(common.T("%s Não foi possível configurar o acesso automático: %v\n", "%s No fue posible configurar el acceso automático: %v\n"), yellow(common.T("AVISO:", "AVISO:")), err)
-				fmt.Println(common.T("\nVocê pode tentar configurar manualmente com os comandos:", "\nPuede intentar configurar manualmente con los comandos:"))
-				fmt.Println("kubectl port-forward -n girus svc/girus-backend 8080:8080 --address 0.0.0.0")
-				fmt.Println("kubectl port-forward -n girus svc/girus-frontend 8000:80 --address 0.0.0.0")
+				fmt.Println(common.T("\nVocê pode tentar configurar manualmente com o comando:", "\nPuede intentar configurar manualmente con el comando:"))
+				fmt.Println("girus port-forward --background")
 			} else {
 				fmt.Printf("%s\n", green(common.T("SUCESSO:", "ÉXITO:")))
 				fmt.Println(common.T("Acesso configurado com sucesso!", "¡Acceso configurado con éxito!"))
-				fmt.Println(bold("Backend:") + " http://localhost:8080")
-				fmt.Println(bold("Frontend:") + " http://localhost:8000")
-
-				// Abrir o navegador se não foi especificado para pular
-				if !skipBrowser {
-					fmt.Println("\n" + headerColor("Abrindo navegador com o Girus..."))
-					if err := helpers.OpenBrowser("http://localhost:8000"); err != nil {
-						fmt.Printf("%s Não foi possível abrir o navegador: %v\n", yellow("AVISO:"), err)
-						fmt.Println("   Acesse manualmente: http://localhost:8000")
-					}
-				}
+				fmt.Println(bold("Backend:") + " " + k8s.BackendURL(topology.Expose))
+				fmt.Println(bold("Frontend:") + " " + accessURL)
 			}
-		} else {
+		default:
+			openBrowser = false
 			fmt.Println("\n" + yellow(common.T("AVISO:", "AVISO:")) + " " + common.T("Port-forward ignorado conforme solicitado", "Port-forward ignorado según lo solicitado"))
 			fmt.Println(common.T("\nPara acessar o Girus posteriormente, execute:", "\nPara acceder a Girus más tarde, ejecute:"))
-			fmt.Println("kubectl port-forward -n girus svc/girus-backend 8080:8080 --address 0.0.0.0")
-			fmt.Println("kubectl port-forward -n girus svc/girus-frontend 8000:80 --address 0.0.0.0")
+			fmt.Println("girus port-forward --background")
+		}
+
+		// Abrir o navegador se não foi especificado para pular
+		if openBrowser {
+			fmt.Println("\n" + headerColor("Abrindo navegador com o Girus..."))
+			if err := helpers.OpenBrowser(accessURL); err != nil {
+				fmt.Printf("%s Não foi possível abrir o navegador: %v\n", yellow("AVISO:"), err)
+				fmt.Println("   Acesse manualmente: " + accessURL)
+			}
 		}
 
 		// Exibir mensagem de conclusão
@@ -789,7 +364,7 @@ Por padrão, o deployment embutido no binário é utilizado.`,
 		// Exibir acesso ao navegador como próximo passo
 		fmt.Println(bold(common.T("PRÓXIMOS PASSOS:", "PRÓXIMOS PASOS:")))
 		fmt.Println(common.T("  • Acesse o Girus no navegador:", "  • Acceda a Girus en el navegador:"))
-		fmt.Println("    http://localhost:8000")
+		fmt.Println("    " + accessURL)
 
 		// Instruções para laboratórios
 		fmt.Println(common.T("\n  • Para aplicar mais templates de laboratórios com o Girus:", "\n  • Para aplicar más plantillas de laboratorio con Girus:"))
@@ -805,7 +380,7 @@ Por padrão, o deployment embutido no binário é utilizado.`,
 var createLabCmd = &cobra.Command{
 	Use:   "lab [lab-id] ou -f [arquivo]",
 	Short: "Cria um novo laboratório no Girus",
-	Long:  "Adiciona um novo laboratório ao Girus a partir de um arquivo de manifesto ConfigMap, ou cria um ambiente de laboratório a partir de um ID de template existente.\nOs templates de laboratório são armazenados no diretório /labs na raiz do projeto.",
+	Long:  "Adiciona um novo laboratório ao Girus a partir de um arquivo lab.yaml, que o CLI empacota em um ConfigMap, ou de um ConfigMap de template já pronto, ou cria um ambiente de laboratório a partir de um ID de template existente.\nOs templates de laboratório são armazenados no diretório /labs na raiz do projeto.",
 	Run: func(cmd *cobra.Command, args []string) {
 		// Criar formatadores de cores
 		red := color.New(color.FgRed).SprintFunc()
@@ -813,11 +388,11 @@ var createLabCmd = &cobra.Command{
 		// Verificar qual modo estamos
 		if labFile != "" {
 			// Modo de adicionar template a partir de arquivo
-			lab.AddLabFromFile(labFile, verboseMode)
+			lab.AddLabFromFile(labFile, labFile, newReporter(verboseMode))
 		} else if len(args) > 0 {
 			// Modo de adicionar template a partir do repositório remoto
 			labID := args[0]
-			createLabFromRepo(labID, repoIndexURL, verboseMode)
+			createLabFromRepo(labID, repoIndexURL, newReporter(verboseMode))
 		} else {
 			fmt.Fprintf(os.Stderr, "%s %s\n", red("ERRO:"), common.T("Você deve especificar um ID de laboratório ou um arquivo com a flag -f", "Debe especificar un ID de laboratorio o un archivo con la opción -f"))
 			fmt.Println(common.T("\nExemplos:", "\nEjemplos:"))
@@ -828,8 +403,237 @@ var createLabCmd = &cobra.Command{
 	},
 }
 
+// clusterTopology combina a seção "cluster" do arquivo de configuração com as
+// flags de topologia; flags informadas explicitamente têm precedência
+func clusterTopology(cmd *cobra.Command) cluster.Topology {
+	cfg := common.LoadConfig().Cluster
+	topology := cluster.Topology{
+		Workers:           cfg.Workers,
+		NodeImage:         cfg.NodeImage,
+		KubernetesVersion: cfg.KubernetesVersion,
+		PortMappings:      cfg.PortMappings,
+		Mounts:            cfg.Mounts,
+		Registry:          cfg.Registry,
+		Expose:            cfg.Expose,
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("workers") {
+		topology.Workers = workerNodes
+	}
+	if flags.Changed("node-image") {
+		topology.NodeImage = nodeImage
+		topology.KubernetesVersion = ""
+	}
+	if flags.Changed("kubernetes-version") {
+		topology.KubernetesVersion = kubernetesVersion
+		if !flags.Changed("node-image") {
+			topology.NodeImage = ""
+		}
+	}
+	if flags.Changed("port-mapping") {
+		topology.PortMappings = portMappings
+	}
+	if flags.Changed("mount") {
+		topology.Mounts = extraMounts
+	}
+	if flags.Changed("with-registry") {
+		topology.Registry = withRegistry
+	}
+	if flags.Changed("expose") {
+		topology.Expose = exposeMode
+	}
+	if topology.Expose == "" {
+		topology.Expose = cluster.ExposePortForward
+	}
+	topology.Ports = requestedPorts(cmd, common.ConfiguredPorts())
+
+	return topology
+}
+
+// printTopology exibe um resumo da topologia que será criada
+func printTopology(topology cluster.Topology) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	magenta := color.New(color.FgMagenta).SprintFunc()
+
+	fmt.Printf("   %s %s\n", cyan(common.T("Nós:", "Nodos:")), magenta(fmt.Sprintf(common.T("1 control-plane, %d worker(s)", "1 control-plane, %d worker(s)"), topology.Workers)))
+	if image := topology.Image(); image != "" {
+		fmt.Printf("   %s %s\n", cyan(common.T("Imagem do nó:", "Imagen del nodo:")), magenta(image))
+	}
+	for _, mapping := range topology.PortMappings {
+		fmt.Printf("   %s %s\n", cyan(common.T("Porta extra:", "Puerto extra:")), magenta(mapping))
+	}
+	for _, mount := range topology.Mounts {
+		fmt.Printf("   %s %s\n", cyan(common.T("Montagem:", "Montaje:")), magenta(mount))
+	}
+	if topology.Registry {
+		fmt.Printf("   %s %s\n", cyan("Registry:"), magenta(cluster.RegistryHost))
+	}
+	if topology.Expose != cluster.ExposePortForward {
+		fmt.Printf("   %s %s\n", cyan(common.T("Acesso:", "Acceso:")), magenta(topology.Expose))
+	}
+}
+
+// loadBundle importa o bundle de imagens no engine de containers local e
+// retorna as imagens que devem ser carregadas nos nós após a criação do
+// cluster. A imagem de nó do bundle é usada quando nenhuma outra foi escolhida.
+func loadBundle(path string, topology *cluster.Topology) []string {
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	magenta := color.New(color.FgMagenta).SprintFunc()
+
+	images, err := bundle.Images(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+		os.Exit(1)
+	}
+
+	fmt.Printf(common.T("Importando bundle %s no %s...\n", "Importando bundle %s en %s...\n"), magenta(path), containerEngine)
+	if err := bundle.Load(path, containerEngine); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+		os.Exit(1)
+	}
+
+	var workloadImages []string
+	for _, image := range images {
+		if strings.HasPrefix(image, cluster.KindNodeImageRepository+":") {
+			if topology.Image() == "" {
+				topology.NodeImage = image
+			}
+			continue
+		}
+		workloadImages = append(workloadImages, image)
+	}
+
+	fmt.Printf(common.T("%s %d imagens importadas do bundle\n", "%s %d imágenes importadas del bundle\n"), green("OK"), len(images))
+	return workloadImages
+}
+
+// addTopologyFlags registra as flags de topologia usadas por clusterTopology
+func addTopologyFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&workerNodes, "workers", "w", 0, common.T("Número de nós worker além do control-plane", "Número de nodos worker además del control-plane"))
+	cmd.Flags().StringVar(&nodeImage, "node-image", "", common.T("Imagem de nó do cluster (ex: kindest/node:v1.33.1 ou rancher/k3s:v1.33.1-k3s1)", "Imagen de nodo del cluster (ej: kindest/node:v1.33.1 o rancher/k3s:v1.33.1-k3s1)"))
+	cmd.Flags().StringVar(&kubernetesVersion, "kubernetes-version", "", common.T("Versão do Kubernetes dos nós (ex: v1.33.1)", "Versión de Kubernetes de los nodos (ej: v1.33.1)"))
+	cmd.Flags().StringArrayVar(&portMappings, "port-mapping", nil, common.T("Porta extra do host para o control-plane no formato [endereço:]portaHost:portaContainer[/protocolo] (pode ser repetida)", "Puerto extra del host hacia el control-plane con el formato [dirección:]puertoHost:puertoContainer[/protocolo] (puede repetirse)"))
+	cmd.Flags().StringArrayVar(&extraMounts, "mount", nil, common.T("Diretório do host montado em todos os nós no formato caminhoHost:caminhoContainer[:ro] (pode ser repetida)", "Directorio del host montado en todos los nodos con el formato rutaHost:rutaContainer[:ro] (puede repetirse)"))
+	cmd.Flags().StringVar(&exposeMode, "expose", cluster.ExposePortForward, common.T("Modo de acesso à aplicação: ", "Modo de acceso a la aplicación: ")+strings.Join(cluster.ExposeModes, "|"))
+	addPortFlags(cmd)
+	cmd.Flags().BoolVar(&withRegistry, "with-registry", false, common.T("Conecta o cluster a um registry local com cache das imagens, mantido entre recriações do cluster", "Conecta el cluster a un registry local con caché de las imágenes, mantenido entre recreaciones del cluster"))
+}
+
+// deployManifest é um manifesto aplicado pelo 'create cluster'
+type deployManifest struct {
+	Name    string
+	Content []byte
+}
+
+// deployManifests retorna os manifestos aplicados pelo 'create cluster': o
+// arquivo informado em --file ou o girus-kind-deploy.yaml encontrado em um dos
+// locais conhecidos (que já contém o template do lab) ou, na falta deles, a
+// infraestrutura e os templates de laboratório embutidos no idioma selecionado
+func deployManifests() ([]deployManifest, error) {
+	if deployFile == "" {
+		deployYamlPath := "girus-kind-deploy.yaml"
+
+		// Verificar em diferentes locais possíveis
+		possiblePaths := []string{
+			deployYamlPath,                      // No diretório atual
+			filepath.Join("..", deployYamlPath), // Um nível acima
+			filepath.Join(os.Getenv("HOME"), "REPOS", "strigus", deployYamlPath), // Caminho comum
+		}
+		for _, path := range possiblePaths {
+			if _, err := os.Stat(path); err == nil {
+				deployFile = path
+				break
+			}
+		}
+	}
+
+	if deployFile != "" {
+		content, err := os.ReadFile(deployFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler o arquivo de deployment: %w", err)
+		}
+		return []deployManifest{{Name: filepath.Base(deployFile), Content: content}}, nil
+	}
+
+	names, err := k8s.EmbeddedManifests()
+	if err != nil {
+		return nil, err
+	}
+	manifests := make([]deployManifest, 0, len(names))
+	for _, name := range names {
+		content, err := templates.GetManifest(name)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar o template %s: %w", name, err)
+		}
+		manifests = append(manifests, deployManifest{Name: name, Content: content})
+	}
+	return manifests, nil
+}
+
+// applyGirusObjects aplica a infraestrutura e os templates de laboratório no
+// cluster com server-side apply, reportando cada objeto aplicado. Falhas nos
+// templates de laboratório geram apenas um aviso; nas demais, o comando é
+// encerrado.
+func applyGirusObjects(objects []*unstructured.Unstructured, r report.Reporter) {
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	applier, err := k8s.NewApplier(common.KubeContext())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+		os.Exit(1)
+	}
+
+	step := common.T("Implantando o Girus", "Desplegando Girus")
+	r.Start(step)
+	applied := 0
+	results := applier.Apply(context.Background(), objects, func(result k8s.ApplyResult) {
+		applied++
+		message := result.String()
+		if result.Err != nil {
+			message += " " + common.T("(falhou)", "(falló)")
+		}
+		r.Progress(step, message, applied, len(objects))
+	})
+
+	labTemplates := 0
+	var infraFailures, labFailures []k8s.ApplyResult
+	for i, result := range results {
+		isLabTemplate := objects[i].GetLabels()["app"] == "girus-lab-template"
+		switch {
+		case result.Err == nil && isLabTemplate:
+			labTemplates++
+		case result.Err != nil && isLabTemplate:
+			labFailures = append(labFailures, result)
+		case result.Err != nil:
+			infraFailures = append(infraFailures, result)
+		}
+	}
+
+	if len(infraFailures) > 0 {
+		r.Fail(step, fmt.Errorf(common.T("%d objetos não puderam ser aplicados", "%d objetos no se pudieron aplicar"), len(infraFailures)))
+		fmt.Fprintf(os.Stderr, "%s %s\n", red("ERRO:"), common.T("Erro ao aplicar o manifesto do Girus:", "Error al aplicar el manifiesto de Girus:"))
+		for _, result := range infraFailures {
+			fmt.Fprintf(os.Stderr, "   %v\n", result.Err)
+		}
+		os.Exit(1)
+	}
+
+	r.Done(step, fmt.Sprintf(common.T("%d objetos aplicados", "%d objetos aplicados"), len(objects)-len(labFailures)))
+	fmt.Printf(common.T("%s Infraestrutura aplicada e %d templates de laboratório instalados.\n", "%s Infraestructura aplicada y %d plantillas de laboratorio instaladas.\n"), green(common.T("SUCESSO:", "ÉXITO:")), labTemplates)
+	if len(labFailures) > 0 {
+		fmt.Printf("%s %s\n", yellow(common.T("AVISO:", "AVISO:")), common.T("Alguns templates de laboratório não puderam ser aplicados:", "Algunas plantillas de laboratorio no se pudieron aplicar:"))
+		for _, result := range labFailures {
+			fmt.Printf("   %v\n", result.Err)
+		}
+	}
+}
+
 // createLabFromRepo baixa e aplica um laboratório do repositório remoto pelo ID
-func createLabFromRepo(labID string, indexURL string, verboseMode bool) {
+func createLabFromRepo(labID string, indexURL string, r report.Reporter) {
 	// Criar formatadores de cores
 	red := color.New(color.FgRed).SprintFunc()
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -859,7 +663,7 @@ func createLabFromRepo(labID string, indexURL string, verboseMode bool) {
 
 	// Aplicar o laboratório
 	fmt.Println(headerColor(common.T("Aplicando laboratório no cluster GIRUS...", "Aplicando laboratorio en el cluster GIRUS...")))
-	lab.AddLabFromFile(tempFile, verboseMode)
+	lab.AddLabFromFile(tempFile, labInfo.URL, r)
 }
 
 func init() {
@@ -868,17 +672,21 @@ func init() {
 
 	// Flags para createClusterCmd
 	createClusterCmd.Flags().StringVarP(&deployFile, "file", "f", "", "Arquivo YAML para deployment do Girus (opcional)")
-	createClusterCmd.Flags().BoolVarP(&verboseMode, "verbose", "v", false, "Modo detalhado com output completo em vez da barra de progresso")
+	createClusterCmd.Flags().BoolVarP(&verboseMode, "verbose", "v", false, common.T("Exibe cada etapa como uma linha de log em vez do spinner (o mesmo que --progress plain)", "Muestra cada etapa como una línea de log en lugar del spinner (lo mismo que --progress plain)"))
 	createClusterCmd.Flags().BoolVarP(&skipPortForward, "skip-port-forward", "", false, "Não perguntar sobre configurar port-forwarding")
 	createClusterCmd.Flags().BoolVarP(&skipBrowser, "skip-browser", "", false, "Não abrir o navegador automaticamente")
 
 	createClusterCmd.Flags().StringVarP(&containerEngine, "container-engine", "e", "docker", "Engine de container (docker ou podman)")
 
+	// Flags de topologia do cluster
+	addTopologyFlags(createClusterCmd)
+	createClusterCmd.Flags().BoolVar(&createDryRun, "dry-run", false, common.T("Apenas exibe a configuração do cluster e os manifestos que seriam aplicados, sem criar nada", "Solo muestra la configuración del cluster y los manifiestos que se aplicarían, sin crear nada"))
+
+	// Flag de criação offline
+	createClusterCmd.Flags().StringVar(&bundleFile, "bundle", "", common.T("Bundle de imagens gerado por 'girus bundle create' para criar o cluster sem internet", "Bundle de imágenes generado por 'girus bundle create' para crear el cluster sin internet"))
+
 	// Flags para createLabCmd
-	createLabCmd.Flags().StringVarP(&labFile, "file", "f", "", "Arquivo de manifesto do laboratório (ConfigMap)")
-	createLabCmd.Flags().BoolVarP(&verboseMode, "verbose", "v", false, "Modo detalhado com output completo em vez da barra de progresso")
+	createLabCmd.Flags().StringVarP(&labFile, "file", "f", "", common.T("Arquivo do laboratório: o lab.yaml ou o ConfigMap de template", "Archivo del laboratorio: el lab.yaml o el ConfigMap de plantilla"))
+	createLabCmd.Flags().BoolVarP(&verboseMode, "verbose", "v", false, common.T("Exibe cada etapa como uma linha de log em vez do spinner (o mesmo que --progress plain)", "Muestra cada etapa como una línea de log en lugar del spinner (lo mismo que --progress plain)"))
 	createLabCmd.Flags().StringVarP(&repoIndexURL, "url", "u", "", "URL do arquivo index.yaml (opcional)")
-
-	// definir o nome do cluster como "girus" sempre
-	clusterName = "girus"
 }
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/doctor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorJSON            bool
+	doctorContainerEngine string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: common.T("Verifica se o ambiente está pronto para o Girus", "Verifica si el entorno está listo para Girus"),
+	Long: common.T(`Verifica as ferramentas (kind, k3d ou minikube e kubectl), a engine de containers,
+as portas 8000/8080, a memória e as CPUs disponíveis, os limites de inotify, a versão
+do cgroup e o acesso ao contexto do cluster. Cada verificação resulta em OK, AVISO ou FALHA;
+o comando termina com erro quando alguma verificação falha.`,
+		`Verifica las herramientas (kind, k3d o minikube y kubectl), la engine de contenedores,
+los puertos 8000/8080, la memoria y las CPUs disponibles, los límites de inotify, la versión
+del cgroup y el acceso al contexto del cluster. Cada verificación resulta en OK, AVISO o FALLO;
+el comando termina con error cuando alguna verificación falla.`),
+	Run: func(cmd *cobra.Command, args []string) {
+		checks := doctor.Run(doctor.Options{
+			ContainerEngine: doctorContainerEngine,
+			Provider:        common.ClusterProvider(),
+			KubeContext:     common.KubeContext(),
+		})
+
+		if doctorJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			encoder.SetEscapeHTML(false)
+			if err := encoder.Encode(checks); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+		} else {
+			fmt.Println(strings.Repeat("─", 80))
+			fmt.Println(headerColor("GIRUS DOCTOR"))
+			fmt.Println(strings.Repeat("─", 80))
+			printChecks(checks)
+		}
+
+		if doctor.Failed(checks) {
+			os.Exit(1)
+		}
+	},
+}
+
+// printChecks exibe o resultado de cada verificação e a dica de correção,
+// quando houver
+func printChecks(checks []doctor.Check) {
+	for _, check := range checks {
+		var status string
+		switch check.Status {
+		case doctor.StatusPass:
+			status = green("OK    ")
+		case doctor.StatusWarn:
+			status = yellow(common.T("AVISO ", "AVISO "))
+		default:
+			status = red(common.T("FALHA ", "FALLO "))
+		}
+		fmt.Printf("   %s %-20s %s\n", status, check.Name, check.Message)
+		if check.Hint != "" && check.Status != doctor.StatusPass {
+			fmt.Printf("          %s %s\n", cyan("→"), check.Hint)
+		}
+	}
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, common.T("Exibe o resultado em JSON", "Muestra el resultado en JSON"))
+	doctorCmd.Flags().StringVarP(&doctorContainerEngine, "container-engine", "e", "docker", common.T("Engine de container (docker ou podman)", "Engine de contenedores (docker o podman)"))
+}
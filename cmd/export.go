@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat    string
+	exportOutDir    string
+	exportNamespace string
+	exportLabs      []string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: common.T("Exporta a plataforma como chart Helm ou base Kustomize", "Exporta la plataforma como chart de Helm o base de Kustomize"),
+	Long: common.T(`Gera um chart Helm ou uma base Kustomize a partir dos manifestos embutidos, para
+implantar o Girus em clusters compartilhados com GitOps. O namespace, as imagens da
+plataforma, os recursos dos pods de laboratório (lab.resources do girus-config) e os
+templates de laboratório incluídos ficam no values.yaml (Helm) ou são aplicados nos
+arquivos gerados (Kustomize, com as imagens no bloco images da kustomization.yaml).`,
+		`Genera un chart de Helm o una base de Kustomize a partir de los manifiestos embebidos,
+para desplegar Girus en clusters compartidos con GitOps. El namespace, las imágenes de la
+plataforma, los recursos de los pods de laboratorio (lab.resources del girus-config) y las
+plantillas de laboratorio incluidas quedan en el values.yaml (Helm) o se aplican en los
+archivos generados (Kustomize, con las imágenes en el bloque images de la kustomization.yaml).`),
+	Example: `  girus export --format helm --out ./girus-chart
+  girus export --format kustomize --out ./girus-base --namespace labs --labs linux-comandos-basicos-lab,docker-fundamentos-lab`,
+	Run: func(cmd *cobra.Command, args []string) {
+		values, err := export.DefaultValues()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+		if exportNamespace != "" {
+			values.Namespace = exportNamespace
+		}
+		if len(exportLabs) > 0 {
+			values.Labs = exportLabs
+		}
+
+		files, err := export.Write(exportFormat, exportOutDir, values)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		for _, file := range files {
+			fmt.Printf("   • %s\n", file)
+		}
+		fmt.Printf(common.T("%s %d arquivos gerados em %s (%d templates de laboratório)\n", "%s %d archivos generados en %s (%d plantillas de laboratorio)\n"),
+			green(common.T("SUCESSO:", "ÉXITO:")), len(files), magenta(exportOutDir), len(values.Labs))
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", export.FormatHelm, common.T("Formato da exportação: ", "Formato de la exportación: ")+strings.Join(export.Formats, "|"))
+	exportCmd.Flags().StringVar(&exportOutDir, "out", "", common.T("Diretório de saída", "Directorio de salida"))
+	exportCmd.Flags().StringVar(&exportNamespace, "namespace", "", common.T("Namespace da plataforma (padrão: girus)", "Namespace de la plataforma (predeterminado: girus)"))
+	exportCmd.Flags().StringSliceVar(&exportLabs, "labs", nil, common.T("Templates de laboratório incluídos, separados por vírgula (padrão: todos)", "Plantillas de laboratorio incluidas, separadas por coma (predeterminado: todas)"))
+	exportCmd.MarkFlagRequired("out")
+}
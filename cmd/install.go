@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/helpers"
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	installKubeconfig string
+	installContext    string
+	forceUninstall    bool
+)
+
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: common.T("Instala o Girus em um cluster Kubernetes existente", "Instala Girus en un cluster Kubernetes existente"),
+	Long: common.T(`Instala o Girus (infraestrutura e templates de laboratório) em um cluster
+Kubernetes existente, como k3s ou um cluster compartilhado de desenvolvimento.
+O cluster nunca é criado nem excluído pelo Girus. Antes de aplicar os manifestos,
+são verificados conflitos de namespace e RBAC com objetos que não pertencem ao Girus.`,
+		`Instala Girus (infraestructura y plantillas de laboratorio) en un cluster
+Kubernetes existente, como k3s o un cluster compartido de desarrollo.
+Girus nunca crea ni elimina el cluster. Antes de aplicar los manifiestos,
+se verifican conflictos de namespace y RBAC con objetos que no pertenecen a Girus.`),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, kubeContext := connectExternalCluster()
+
+		fmt.Println("\n" + headerColor(common.T("Verificando conflitos...", "Verificando conflictos...")))
+		objects, err := k8s.InstallObjects()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		conflicts, err := client.FindInstallConflicts(context.Background(), objects)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+		if len(conflicts) > 0 {
+			fmt.Fprintf(os.Stderr, "%s %s\n", red(common.T("ERRO:", "ERROR:")),
+				common.T("Os seguintes objetos já existem no cluster e não foram criados pelo Girus:",
+					"Los siguientes objetos ya existen en el cluster y no fueron creados por Girus:"))
+			for _, conflict := range conflicts {
+				fmt.Fprintf(os.Stderr, "   • %s/%s\n", conflict.Kind, magenta(conflict.Name))
+			}
+			fmt.Fprintln(os.Stderr, common.T("   Remova-os ou use outro cluster. Nenhuma alteração foi feita.",
+				"   Elimínelos o use otro cluster. No se realizó ningún cambio."))
+			os.Exit(1)
+		}
+		fmt.Printf("%s %s\n", green("OK"), common.T("Nenhum conflito encontrado", "No se encontraron conflictos"))
+
+		fmt.Println("\n" + headerColor(common.T("Implantando o Girus no cluster...", "Desplegando Girus en el cluster...")))
+		applyObjects(objects)
+		fmt.Printf(common.T("%s %d objetos aplicados\n", "%s %d objetos aplicados\n"), green("OK"), len(objects))
+
+		if err := k8s.WaitForPodsReady("girus", 5*time.Minute, newReporter(false)); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", yellow(common.T("AVISO:", "AVISO:")), err)
+		}
+
+		// O contexto resolvido vai explícito no comando, mesmo sem --context,
+		// para que ele continue apontando para este cluster se o
+		// current-context do kubeconfig mudar
+		portForwardFlags := []string{"--context", kubeContext}
+		if installKubeconfig != "" {
+			portForwardFlags = append([]string{"--kubeconfig", installKubeconfig}, portForwardFlags...)
+		}
+		fmt.Println("\n" + green(common.T("SUCESSO:", "ÉXITO:")) + " " + fmt.Sprintf(common.T("Girus instalado no contexto %s!", "¡Girus instalado en el contexto %s!"), magenta(kubeContext)))
+		fmt.Println(common.T("\nPara acessar o Girus, execute:", "\nPara acceder a Girus, ejecute:"))
+		fmt.Printf("girus port-forward %s\n", strings.Join(portForwardFlags, " "))
+	},
+}
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: common.T("Remove o Girus de um cluster Kubernetes existente", "Elimina Girus de un cluster Kubernetes existente"),
+	Long: common.T(`Remove de um cluster existente apenas os objetos criados por 'girus install'
+(identificados pelo rótulo app.kubernetes.io/managed-by=girus). O cluster é mantido.`,
+		`Elimina de un cluster existente solo los objetos creados por 'girus install'
+(identificados por la etiqueta app.kubernetes.io/managed-by=girus). El cluster se mantiene.`),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, kubeContext := connectExternalCluster()
+
+		if !forceUninstall {
+			fmt.Printf(common.T("%s Você está prestes a remover o Girus do contexto %s.\n",
+				"%s Está a punto de eliminar Girus del contexto %s.\n"),
+				yellow(common.T("AVISO:", "AVISO:")), magenta(kubeContext))
+			if !helpers.Confirm(common.T("Deseja continuar?", "¿Desea continuar?"), false) {
+				fmt.Println(common.T("Operação cancelada pelo usuário.", "Operación cancelada por el usuario."))
+				return
+			}
+		}
+
+		removed, err := client.Uninstall(context.Background())
+		for _, object := range removed {
+			fmt.Printf("   %s %s\n", green(common.T("REMOVIDO", "ELIMINADO")), object)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		if len(removed) == 0 {
+			fmt.Printf("%s %s\n", yellow(common.T("AVISO:", "AVISO:")),
+				common.T("Nenhum objeto instalado pelo Girus foi encontrado neste cluster.",
+					"No se encontró ningún objeto instalado por Girus en este cluster."))
+			return
+		}
+		fmt.Println("\n" + green(common.T("SUCESSO:", "ÉXITO:")) + " " + common.T("Girus removido do cluster.", "Girus eliminado del cluster."))
+	},
+}
+
+// applyObjects aplica os objetos no cluster selecionado com server-side
+// apply, o mesmo usado por 'girus create cluster', e encerra o comando
+// listando os objetos que falharam
+func applyObjects(objects []*unstructured.Unstructured) {
+	applier, err := k8s.NewApplier(common.KubeContext())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+		os.Exit(1)
+	}
+
+	var failures []k8s.ApplyResult
+	for _, result := range applier.Apply(context.Background(), objects, nil) {
+		if result.Err != nil {
+			failures = append(failures, result)
+		}
+	}
+	if len(failures) > 0 {
+		fmt.Fprintf(os.Stderr, "%s %s\n", red(common.T("ERRO:", "ERROR:")), fmt.Sprintf(common.T("%d objetos não puderam ser aplicados:", "%d objetos no se pudieron aplicar:"), len(failures)))
+		for _, result := range failures {
+			fmt.Fprintf(os.Stderr, "   %v\n", result.Err)
+		}
+		os.Exit(1)
+	}
+}
+
+// connectExternalCluster seleciona o cluster existente informado pelas flags
+// --kubeconfig/--context e confirma que sua API está acessível
+func connectExternalCluster() (*k8s.KubernetesClient, string) {
+	common.SetExternalCluster(installKubeconfig, installContext)
+
+	kubeContext, err := k8s.CurrentContext()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+		os.Exit(1)
+	}
+
+	client, err := k8s.NewKubernetesClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s: %v\n", red(common.T("ERRO:", "ERROR:")), common.T("Erro ao criar cliente Kubernetes", "Error al crear cliente de Kubernetes"), err)
+		os.Exit(1)
+	}
+
+	version, err := client.ServerVersion()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s %s: %v\n", red(common.T("ERRO:", "ERROR:")), common.T("Não foi possível acessar o contexto", "No fue posible acceder al contexto"), magenta(kubeContext), err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s %s %s (Kubernetes %s)\n", cyan("INFO:"), common.T("Usando o contexto", "Usando el contexto"), magenta(kubeContext), version)
+
+	return client, kubeContext
+}
+
+func init() {
+	for _, c := range []*cobra.Command{installCmd, uninstallCmd} {
+		c.Flags().StringVar(&installKubeconfig, "kubeconfig", "", common.T("caminho do kubeconfig (padrão: $KUBECONFIG ou ~/.kube/config)", "ruta del kubeconfig (predeterminado: $KUBECONFIG o ~/.kube/config)"))
+		c.Flags().StringVar(&installContext, "context", "", common.T("contexto do kubeconfig (padrão: current-context)", "contexto del kubeconfig (predeterminado: current-context)"))
+	}
+	uninstallCmd.Flags().BoolVarP(&forceUninstall, "force", "f", false, common.T("Remove sem confirmação", "Elimina sin confirmación"))
+}
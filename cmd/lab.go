@@ -3,11 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/k8s"
 	"github.com/badtuxx/girus-cli/internal/repo"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -45,6 +45,11 @@ var labListCmd = &cobra.Command{
 			return fmt.Errorf("%s %v", red(common.T("ERRO:", "ERROR:")), err)
 		}
 
+		// Na saída estruturada, os laboratórios ficam agrupados pelo nome do repositório
+		if structuredOutput() {
+			return printStructured(labs)
+		}
+
 		fmt.Println(headerColor(common.T("LABORATÓRIOS DISPONÍVEIS", "LABORATORIOS DISPONIBLES")))
 		fmt.Println(strings.Repeat("─", 80))
 
@@ -111,14 +116,14 @@ var labInstallCmd = &cobra.Command{
 		fmt.Println(strings.Repeat("─", 80))
 		fmt.Println(common.T("Reiniciando o backend para aplicar as mudanças...", "Reiniciando el backend para aplicar los cambios..."))
 
-		restartCmd := exec.Command("kubectl", "rollout", "restart", "deployment/girus-backend", "-n", "girus")
+		restartCmd := k8s.KubectlCommand("rollout", "restart", "deployment/girus-backend", "-n", "girus")
 		if err := restartCmd.Run(); err != nil {
 			return fmt.Errorf("%s %s: %v", red("ERRO:"), common.T("Erro ao reiniciar o backend", "Error al reiniciar el backend"), err)
 		}
 
 		// Aguarda o reinício completar
 		fmt.Println(common.T("Aguardando o reinício do backend completar...", "Esperando a que el backend reinicie por completo..."))
-		waitCmd := exec.Command("kubectl", "rollout", "status", "deployment/girus-backend", "-n", "girus", "--timeout=60s")
+		waitCmd := k8s.KubectlCommand("rollout", "status", "deployment/girus-backend", "-n", "girus", "--timeout=60s")
 		if err := waitCmd.Run(); err != nil {
 			return fmt.Errorf("%s %s: %v", red("ERRO:"), common.T("Erro ao aguardar reinício do backend", "Error al esperar el reinicio del backend"), err)
 		}
@@ -157,6 +162,18 @@ var labSearchCmd = &cobra.Command{
 			return fmt.Errorf("%s %s: %v", red(common.T("ERRO:", "ERROR:")), common.T("Erro ao listar laboratórios", "Error al listar laboratorios"), err)
 		}
 
+		if structuredOutput() {
+			matches := map[string][]repo.LabEntry{}
+			for repoName, entries := range labs {
+				for _, entry := range entries {
+					if matchesLab(entry, term) {
+						matches[repoName] = append(matches[repoName], entry)
+					}
+				}
+			}
+			return printStructured(matches)
+		}
+
 		fmt.Println(headerColor(common.T("BUSCA DE LABORATÓRIOS", "BÚSQUEDA DE LABORATORIOS")))
 		fmt.Println(strings.Repeat("─", 80))
 		fmt.Printf(common.T("Buscando por: %s\n\n", "Buscando por: %s\n\n"), magenta(term))
@@ -167,10 +184,7 @@ var labSearchCmd = &cobra.Command{
 		found := false
 		for repoName, entries := range labs {
 			for _, entry := range entries {
-				// Verifica se o termo está no título, descrição ou tags
-				if containsCaseInsensitive(entry.Title, term) ||
-					containsCaseInsensitive(entry.Description, term) ||
-					containsCaseInsensitive(entry.Tags, term) {
+				if matchesLab(entry, term) {
 					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
 						magenta(entry.ID),
 						entry.Version,
@@ -199,6 +213,13 @@ func init() {
 	labInstallCmd.Flags().String("version", "", common.T("Versão específica do laboratório", "Versión específica del laboratorio"))
 }
 
+// matchesLab verifica se o termo está no título, descrição ou tags do laboratório
+func matchesLab(entry repo.LabEntry, term string) bool {
+	return containsCaseInsensitive(entry.Title, term) ||
+		containsCaseInsensitive(entry.Description, term) ||
+		containsCaseInsensitive(entry.Tags, term)
+}
+
 // containsCaseInsensitive verifica se uma string está contida em outra, ignorando maiúsculas/minúsculas
 func containsCaseInsensitive(s interface{}, term string) bool {
 	switch v := s.(type) {
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/lablint"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFix       bool
+	lintRules     map[string]string
+	lintSARIF     string
+	lintListRules bool
+)
+
+var labLintCmd = &cobra.Command{
+	Use:   "lint [arquivo ou diretório...]",
+	Short: common.T("Aponta erros comuns de autoria nos laboratórios", "Señala errores comunes de autoría en los laboratorios"),
+	Long: common.T(`Aplica aos templates de laboratório as regras de boas práticas de autoria,
+além da validação do schema: tarefas sem validation, comandos de validação que
+sempre passam, comandos fora de crases nos passos e tipos de dica
+desconhecidos. Diretórios são percorridos em busca dos ConfigMaps de
+laboratório e dos lab.yaml puros.
+
+Cada regra tem um ID e um nível (error, warning, info ou off), que pode ser
+alterado com --rule ou no bloco lint.rules do arquivo de configuração. O
+comando termina com código 1 quando sobra algum problema de nível error.`,
+		`Aplica a las plantillas de laboratorio las reglas de buenas prácticas de
+autoría, además de la validación del schema: tareas sin validation, comandos de
+validación que siempre pasan, comandos sin comillas invertidas en los pasos y
+tipos de consejo desconocidos. Los directorios se recorren en busca de los
+ConfigMaps de laboratorio y de los lab.yaml puros.
+
+Cada regla tiene un ID y un nivel (error, warning, info u off), que se puede
+cambiar con --rule o en el bloque lint.rules del archivo de configuración. El
+comando termina con código 1 cuando queda algún problema de nivel error.`),
+	Example: `  girus lab lint internal/templates/manifests
+  girus lab lint labs/meu-lab --fix
+  girus lab lint labs --rule task-without-validation=error --sarif lint.sarif`,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries := map[string]string{}
+		for id, level := range common.LoadConfig().Lint.Rules {
+			entries[id] = level
+		}
+		for id, level := range lintRules {
+			entries[id] = level
+		}
+		config, err := lablint.ParseConfig(entries)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		if lintListRules {
+			listLintRules(config)
+			return
+		}
+		if len(args) == 0 {
+			fmt.Fprintf(os.Stderr, "%s %s\n", red(common.T("ERRO:", "ERROR:")), common.T("informe ao menos um arquivo ou diretório", "indique al menos un archivo o directorio"))
+			os.Exit(1)
+		}
+
+		files, err := labFiles(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		var results []lablint.Result
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+			findings := lablint.Lint(data, config)
+			if lintFix {
+				findings = fixLabFile(file, data, findings, config)
+			}
+			results = append(results, lablint.Result{File: file, Findings: findings})
+		}
+
+		if lintSARIF != "" {
+			if err := writeLintSARIF(results, config); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+		}
+
+		switch {
+		case structuredOutput():
+			exitOnOutputError(printStructured(results))
+		case lintSARIF != "-":
+			printLintResults(results)
+		}
+
+		for _, result := range results {
+			for _, finding := range result.Findings {
+				if finding.Severity == lablint.SeverityError {
+					os.Exit(1)
+				}
+			}
+		}
+	},
+}
+
+// labFiles expande os argumentos em arquivos de laboratório. Arquivos são
+// usados como informados; nos diretórios, apenas os templates (ConfigMaps ou
+// lab.yaml puros).
+func labFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		err = filepath.WalkDir(arg, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if labschema.IsTemplate(data) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// fixLabFile aplica as correções automáticas no arquivo e retorna os
+// problemas que restaram
+func fixLabFile(file string, data []byte, findings []lablint.Finding, config lablint.Config) []lablint.Finding {
+	fixed, applied, err := lablint.Fix(data, findings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s: %v\n", yellow(common.T("AVISO:", "AVISO:")), file, err)
+		return findings
+	}
+	if applied == 0 {
+		return findings
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(file, fixed, info.Mode().Perm()); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, common.T("%s %s: %d correções aplicadas\n", "%s %s: %d correcciones aplicadas\n"), green("🔧"), file, applied)
+	return lablint.Lint(fixed, config)
+}
+
+// writeLintSARIF grava o relatório SARIF no arquivo de --sarif, ou no stdout com "-"
+func writeLintSARIF(results []lablint.Result, config lablint.Config) error {
+	version := common.ShortVersion()
+	if lintSARIF == "-" {
+		return lablint.WriteSARIF(os.Stdout, version, results, config)
+	}
+	out, err := os.Create(lintSARIF)
+	if err != nil {
+		return fmt.Errorf("falha ao criar o relatório SARIF: %w", err)
+	}
+	defer out.Close()
+	return lablint.WriteSARIF(out, version, results, config)
+}
+
+// printLintResults exibe os problemas de cada arquivo e o resumo
+func printLintResults(results []lablint.Result) {
+	counts := map[lablint.Severity]int{}
+	fixable := 0
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			symbol := cyan("ℹ")
+			switch finding.Severity {
+			case lablint.SeverityError:
+				symbol = red("✗")
+			case lablint.SeverityWarning:
+				symbol = yellow("⚠")
+			}
+			fmt.Printf("%s %s:%s\n", symbol, result.File, finding)
+			counts[finding.Severity]++
+			if finding.Fixable {
+				fixable++
+			}
+		}
+	}
+
+	total := counts[lablint.SeverityError] + counts[lablint.SeverityWarning] + counts[lablint.SeverityInfo]
+	if total == 0 {
+		fmt.Printf(common.T("%s %d arquivos sem problemas\n", "%s %d archivos sin problemas\n"), green("✓"), len(results))
+		return
+	}
+	fmt.Printf(common.T("\n%d erros, %d avisos e %d notas em %d arquivos\n", "\n%d errores, %d avisos y %d notas en %d archivos\n"),
+		counts[lablint.SeverityError], counts[lablint.SeverityWarning], counts[lablint.SeverityInfo], len(results))
+	if fixable > 0 && !lintFix {
+		fmt.Printf(common.T("%d problemas podem ser corrigidos com --fix\n", "%d problemas se pueden corregir con --fix\n"), fixable)
+	}
+}
+
+// listLintRules exibe as regras com o nível em uso
+func listLintRules(config lablint.Config) {
+	rules := make([]lablint.Rule, len(lablint.Rules))
+	copy(rules, lablint.Rules)
+	for i := range rules {
+		if severity, ok := config[rules[i].ID]; ok {
+			rules[i].Severity = severity
+		}
+	}
+	if structuredOutput() {
+		exitOnOutputError(printStructured(rules))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, cyan("REGRA")+"\t"+cyan("NÍVEL")+"\t"+cyan("--FIX")+"\t"+cyan("DESCRIÇÃO"))
+	for _, rule := range rules {
+		fix := ""
+		if rule.Fixable {
+			fix = common.T("sim", "sí")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", magenta(rule.ID), rule.Severity, fix, rule.Description)
+	}
+	w.Flush()
+	fmt.Println(strings.Repeat("─", 80))
+	fmt.Println(common.T("Altere o nível com --rule ID=nível ou no bloco lint.rules do arquivo de configuração.", "Cambie el nivel con --rule ID=nivel o en el bloque lint.rules del archivo de configuración."))
+}
+
+func init() {
+	labCmd.AddCommand(labLintCmd)
+
+	labLintCmd.Flags().BoolVar(&lintFix, "fix", false, common.T("Corrige automaticamente os problemas mecânicos, editando os arquivos", "Corrige automáticamente los problemas mecánicos, editando los archivos"))
+	labLintCmd.Flags().StringToStringVar(&lintRules, "rule", nil, common.T("Nível de uma regra, como task-without-validation=error (error, warning, info ou off)", "Nivel de una regla, como task-without-validation=error (error, warning, info u off)"))
+	labLintCmd.Flags().StringVar(&lintSARIF, "sarif", "", common.T("Grava o relatório SARIF 2.1.0 no arquivo (\"-\" para o stdout)", "Guarda el informe SARIF 2.1.0 en el archivo (\"-\" para el stdout)"))
+	labLintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, common.T("Lista as regras e o nível de cada uma", "Lista las reglas y el nivel de cada una"))
+}
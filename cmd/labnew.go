@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/helpers"
+	"github.com/badtuxx/girus-cli/internal/labscaffold"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newLabTitle    string
+	newLabDuration string
+	newLabImage    string
+	newLabCategory string
+	newLabTasks    int
+	newLabDir      string
+	newLabForce    bool
+)
+
+var labNewCmd = &cobra.Command{
+	Use:   "new <id>",
+	Short: common.T("Cria o esqueleto de um novo laboratório", "Crea el esqueleto de un nuevo laboratorio"),
+	Long: common.T(`Gera os ConfigMaps de um novo laboratório, em português e em espanhol, no
+layout labs/<id>/lab.yaml e labs/<id>/lab_es.yaml. Os arquivos já seguem o
+schema de 'girus lab validate' e trazem uma tarefa de exemplo, com passos,
+dica e validação, para cada tarefa pedida.
+
+Em um terminal, o comando pergunta o título, a duração, a imagem base, a
+categoria e o número de tarefas que não foram informados pelas flags. Com
+--yes ou sem terminal, usa as flags e os valores padrão. A categoria é lida
+do prefixo do ID (linux_meu-lab é da categoria linux) e define a imagem
+padrão.`,
+		`Genera los ConfigMaps de un nuevo laboratorio, en portugués y en español, en
+el layout labs/<id>/lab.yaml y labs/<id>/lab_es.yaml. Los archivos ya siguen el
+schema de 'girus lab validate' y traen una tarea de ejemplo, con pasos, consejo
+y validación, para cada tarea pedida.
+
+En una terminal, el comando pregunta el título, la duración, la imagen base, la
+categoría y el número de tareas que no se indicaron con las flags. Con --yes o
+sin terminal, usa las flags y los valores por defecto. La categoría se lee del
+prefijo del ID (linux_mi-lab es de la categoría linux) y define la imagen por
+defecto.`),
+	Example: `  girus lab new linux_meu-lab
+  girus lab new docker_redes --title "Redes no Docker" --duration 45m --tasks 4 --yes`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := newLabOptions(cmd, args[0])
+		if err == nil {
+			err = options.Validate()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		paths, err := labscaffold.Write(newLabDir, options, newLabForce)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		fmt.Println()
+		for _, path := range paths {
+			fmt.Printf("%s %s\n", green("✓"), path)
+		}
+		fmt.Println("\n" + headerColor(common.T("PRÓXIMOS PASSOS", "PRÓXIMOS PASOS")))
+		fmt.Println(strings.Repeat("─", 80))
+		fmt.Println(common.T("1. Escreva as tarefas, os passos e as validações nos dois arquivos", "1. Escriba las tareas, los pasos y las validaciones en los dos archivos"))
+		fmt.Printf(common.T("2. Confira os arquivos: %s\n", "2. Revise los archivos: %s\n"), magenta("girus lab lint "+strings.Join(paths, " ")))
+		fmt.Printf(common.T("3. Instale no cluster: %s\n", "3. Instálelo en el cluster: %s\n"), magenta("girus create lab -f "+paths[0]))
+	},
+}
+
+// newLabOptions junta as flags e, em um terminal, as respostas às perguntas
+// sobre o que não foi informado
+func newLabOptions(cmd *cobra.Command, id string) (labscaffold.Options, error) {
+	options := labscaffold.Options{
+		ID:       id,
+		Title:    newLabTitle,
+		Duration: newLabDuration,
+		Image:    newLabImage,
+		Category: newLabCategory,
+		Tasks:    newLabTasks,
+	}
+	if options.Category == "" {
+		options.Category = labscaffold.CategoryOf(id)
+	}
+
+	// Sem perguntas, as flags obrigatórias são conferidas antes de tudo
+	errNoTitle := errors.New(common.T("informe o título do laboratório com --title", "indique el título del laboratorio con --title"))
+	if !helpers.Interactive() && options.Title == "" {
+		return options, errNoTitle
+	}
+
+	ask := func(flag, question, value string) string {
+		if cmd.Flags().Changed(flag) {
+			return value
+		}
+		return strings.TrimSpace(helpers.Ask(question, value))
+	}
+
+	if helpers.Interactive() {
+		fmt.Println(headerColor(common.T("NOVO LABORATÓRIO", "NUEVO LABORATORIO")) + " " + magenta(id))
+		fmt.Println(strings.Repeat("─", 80))
+	}
+	options.Title = ask("title", common.T("Título", "Título"), options.Title)
+	options.Duration = ask("duration", common.T("Duração", "Duración"), options.Duration)
+	options.Category = ask("category", common.T("Categoria", "Categoría")+" ("+strings.Join(labscaffold.CategoryNames(), ", ")+")", options.Category)
+	if options.Image == "" {
+		options.Image = labscaffold.Categories[options.Category].Image
+	}
+	options.Image = ask("image", common.T("Imagem base", "Imagen base"), options.Image)
+
+	tasks := ask("tasks", common.T("Número de tarefas", "Número de tareas"), strconv.Itoa(options.Tasks))
+	count, err := strconv.Atoi(tasks)
+	if err != nil {
+		return options, fmt.Errorf(common.T("número de tarefas inválido %q", "número de tareas inválido %q"), tasks)
+	}
+	options.Tasks = count
+
+	if options.Title == "" {
+		return options, errNoTitle
+	}
+	return options, nil
+}
+
+func init() {
+	labCmd.AddCommand(labNewCmd)
+
+	labNewCmd.Flags().StringVar(&newLabTitle, "title", "", common.T("Título do laboratório", "Título del laboratorio"))
+	labNewCmd.Flags().StringVar(&newLabDuration, "duration", "30m", common.T("Duração do laboratório, como 30m ou 1h30m", "Duración del laboratorio, como 30m o 1h30m"))
+	labNewCmd.Flags().StringVar(&newLabImage, "image", "", common.T("Imagem base do ambiente (padrão: a imagem da categoria)", "Imagen base del entorno (por defecto: la imagen de la categoría)"))
+	labNewCmd.Flags().StringVar(&newLabCategory, "category", "", common.T("Categoria do laboratório: ", "Categoría del laboratorio: ")+strings.Join(labscaffold.CategoryNames(), ", ")+common.T(" (padrão: o prefixo do ID)", " (por defecto: el prefijo del ID)"))
+	labNewCmd.Flags().IntVar(&newLabTasks, "tasks", 3, common.T("Número de tarefas", "Número de tareas"))
+	labNewCmd.Flags().StringVar(&newLabDir, "dir", "labs", common.T("Diretório base dos laboratórios", "Directorio base de los laboratorios"))
+	labNewCmd.Flags().BoolVar(&newLabForce, "force", false, common.T("Sobrescreve os arquivos existentes", "Sobrescribe los archivos existentes"))
+}
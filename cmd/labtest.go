@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+	"github.com/badtuxx/girus-cli/internal/labtest"
+	"github.com/badtuxx/girus-cli/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testRuntime      string
+	testNamespace    string
+	testTimeout      time.Duration
+	testStartTimeout time.Duration
+	testJUnit        string
+	testKeep         bool
+)
+
+var labTestCmd = &cobra.Command{
+	Use:   "test <arquivo...>",
+	Short: common.T("Testa as validações de um laboratório sem o aluno", "Prueba las validaciones de un laboratorio sin el alumno"),
+	Long: common.T(`Inicia a imagem do laboratório em um pod efêmero no cluster atual, ou em um
+contêiner local com --runtime docker|podman, e executa as tarefas em ordem:
+primeiro o script "solution" da tarefa, depois cada comando de validation,
+comparando a saída com expectedOutput ou expectedExpression.
+
+O resultado de cada tarefa é exibido ao final dela e pode ser gravado em
+JUnit XML com --junit, para uso na CI. Um laboratório cujo ambiente não
+inicia entra no relatório como falha, e os demais continuam sendo testados.
+O comando termina com código 1 quando alguma tarefa ou ambiente falha.
+Tarefas sem solution são apenas validadas; tarefas sem validation aparecem
+como ignoradas.`,
+		`Inicia la imagen del laboratorio en un pod efímero en el cluster actual, o en
+un contenedor local con --runtime docker|podman, y ejecuta las tareas en orden:
+primero el script "solution" de la tarea, después cada comando de validation,
+comparando la salida con expectedOutput o expectedExpression.
+
+El resultado de cada tarea se muestra al final de ella y se puede guardar en
+JUnit XML con --junit, para uso en CI. Un laboratorio cuyo entorno no inicia
+entra en el informe como fallo, y los demás se siguen probando. El comando
+termina con código 1 cuando alguna tarea o entorno falla. Las tareas sin
+solution solo se validan; las tareas sin validation aparecen como omitidas.`),
+	Example: `  girus lab test labs/linux_comandos-basicos/lab.yaml
+  girus lab test labs/docker_volumes/lab.yaml --runtime docker --junit junit.xml`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if testRuntime != "cluster" && testRuntime != "docker" && testRuntime != "podman" {
+			fmt.Fprintf(os.Stderr, "%s %s\n", red(common.T("ERRO:", "ERROR:")), fmt.Sprintf(common.T("runtime inválido %q: use cluster, docker ou podman", "runtime inválido %q: use cluster, docker o podman"), testRuntime))
+			os.Exit(1)
+		}
+
+		// Com o relatório no stdout, as mensagens de progresso vão para o stderr
+		var out io.Writer = os.Stdout
+		r := newReporter(false)
+		if structuredOutput() || testJUnit == "-" {
+			out = os.Stderr
+			r = report.NewPlain(os.Stderr)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		var reports []*labtest.Report
+		failed := false
+		for _, file := range args {
+			// Um laboratório que não pôde ser testado entra no relatório como
+			// falha, e os demais arquivos continuam sendo testados
+			result, err := testLabFile(ctx, file, out, r)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n\n", red(common.T("ERRO:", "ERROR:")), file, err)
+			}
+			reports = append(reports, result)
+			failed = failed || !result.Passed()
+			// Com Ctrl+C, os laboratórios restantes não são iniciados
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		if testJUnit != "" {
+			if err := writeTestJUnit(reports); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+		}
+		if structuredOutput() {
+			exitOnOutputError(printStructured(reports))
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// testLabFile testa um arquivo de laboratório em um ambiente novo, removido
+// ao final, a menos que --keep seja usado. O relatório é retornado mesmo
+// quando o erro impede as tarefas de rodar.
+func testLabFile(ctx context.Context, file string, out io.Writer, r report.Reporter) (*labtest.Report, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return labtest.Failed(file, "", testRuntime, err), err
+	}
+	doc, err := labschema.Load(data)
+	if err != nil {
+		var invalid *labschema.ValidationError
+		if errors.As(err, &invalid) {
+			err = fmt.Errorf(common.T("%w (use 'girus lab validate' para ver todos os problemas)", "%w (use 'girus lab validate' para ver todos los problemas)"), err)
+		}
+		return labtest.Failed(file, "", testRuntime, err), err
+	}
+	lab := &doc.Lab
+
+	spec := labtest.NewSpec(lab)
+	var env labtest.Environment = &labtest.Pod{Spec: spec, Namespace: testNamespace, Timeout: testStartTimeout}
+	if testRuntime != "cluster" {
+		env = &labtest.Container{Spec: spec, Engine: testRuntime}
+	}
+
+	fmt.Fprintln(out, headerColor(common.T("TESTANDO LABORATÓRIO", "PROBANDO LABORATORIO"))+" "+magenta(lab.Name))
+	fmt.Fprintln(out, strings.Repeat("─", 80))
+
+	defer func() {
+		if testKeep {
+			fmt.Fprintf(out, common.T("%s Ambiente mantido para inspeção: %s\n", "%s Entorno mantenido para inspección: %s\n"), cyan("INFO:"), env)
+			return
+		}
+		// A remoção não depende do Ctrl+C que pode ter cancelado o teste
+		if err := env.Stop(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", yellow(common.T("AVISO:", "AVISO:")), err)
+		}
+	}()
+
+	step := fmt.Sprintf(common.T("Iniciando %s com a imagem %s", "Iniciando %s con la imagen %s"), env, lab.Image)
+	started := time.Now()
+	if err := report.Run(r, step, func() error { return env.Start(ctx) }); err != nil {
+		result := labtest.Failed(lab.Name, lab.Title, env.String(), err)
+		result.Seconds = time.Since(started).Seconds()
+		return result, err
+	}
+
+	total := len(lab.Tasks)
+	index := 0
+	result := labtest.Run(ctx, env, lab, labtest.Options{
+		Timeout: testTimeout,
+		OnTask: func(task labtest.TaskResult) {
+			index++
+			printTaskResult(out, index, total, task)
+		},
+	})
+
+	passed, failed, skipped := 0, 0, 0
+	for _, task := range result.Tasks {
+		switch task.Status {
+		case labtest.StatusPassed:
+			passed++
+		case labtest.StatusFailed:
+			failed++
+		default:
+			skipped++
+		}
+	}
+	fmt.Fprintln(out, strings.Repeat("─", 80))
+	summary := fmt.Sprintf(common.T("%d tarefas: %d passaram, %d falharam e %d ignoradas em %.1fs", "%d tareas: %d pasaron, %d fallaron y %d omitidas en %.1fs"), total, passed, failed, skipped, result.Seconds)
+	if failed > 0 {
+		fmt.Fprintf(out, "%s %s\n\n", red("✗"), summary)
+	} else {
+		fmt.Fprintf(out, "%s %s\n\n", green("✓"), summary)
+	}
+	return result, nil
+}
+
+// printTaskResult exibe o resultado da tarefa e o detalhe das validações que falharam
+func printTaskResult(out io.Writer, index, total int, task labtest.TaskResult) {
+	symbol := green("✓")
+	switch task.Status {
+	case labtest.StatusFailed:
+		symbol = red("✗")
+	case labtest.StatusSkipped:
+		symbol = yellow("-")
+	}
+	fmt.Fprintf(out, "%s [%d/%d] %s (%.1fs)\n", symbol, index, total, task.Name, task.Seconds)
+	if task.Message != "" {
+		fmt.Fprintf(out, "    %s\n", task.Message)
+	}
+	for _, validation := range task.Validations {
+		if validation.Status == labtest.StatusFailed {
+			fmt.Fprintf(out, "    %s %s\n      %s\n", red("✗"), validation.Command, validation.Message)
+		}
+	}
+}
+
+// writeTestJUnit grava o relatório JUnit no arquivo de --junit, ou no stdout com "-"
+func writeTestJUnit(reports []*labtest.Report) error {
+	if testJUnit == "-" {
+		return labtest.WriteJUnit(os.Stdout, reports)
+	}
+	out, err := os.Create(testJUnit)
+	if err != nil {
+		return fmt.Errorf("falha ao criar o relatório JUnit: %w", err)
+	}
+	defer out.Close()
+	return labtest.WriteJUnit(out, reports)
+}
+
+func init() {
+	labCmd.AddCommand(labTestCmd)
+
+	labTestCmd.Flags().StringVar(&testRuntime, "runtime", "cluster", common.T("Onde executar o laboratório: cluster (pod efêmero), docker ou podman", "Dónde ejecutar el laboratorio: cluster (pod efímero), docker o podman"))
+	labTestCmd.Flags().StringVar(&testNamespace, "namespace", "default", common.T("Namespace do pod de teste, com --runtime cluster", "Namespace del pod de prueba, con --runtime cluster"))
+	labTestCmd.Flags().DurationVar(&testTimeout, "timeout", 2*time.Minute, common.T("Tempo máximo de cada script de solução e de cada validação", "Tiempo máximo de cada script de solución y de cada validación"))
+	labTestCmd.Flags().DurationVar(&testStartTimeout, "start-timeout", 5*time.Minute, common.T("Tempo máximo para o pod de teste ficar pronto", "Tiempo máximo para que el pod de prueba esté listo"))
+	labTestCmd.Flags().StringVar(&testJUnit, "junit", "", common.T("Grava o relatório JUnit XML no arquivo (\"-\" para o stdout)", "Guarda el informe JUnit XML en el archivo (\"-\" para el stdout)"))
+	labTestCmd.Flags().BoolVar(&testKeep, "keep", false, common.T("Mantém o pod ou contêiner ao final, para inspeção", "Mantiene el pod o contenedor al final, para inspección"))
+}
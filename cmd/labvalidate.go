@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+	"github.com/spf13/cobra"
+)
+
+// labValidation é o resultado da validação de um arquivo na saída estruturada
+type labValidation struct {
+	File   string            `json:"file"`
+	Valid  bool              `json:"valid"`
+	Issues []labschema.Issue `json:"issues"`
+}
+
+var labValidateCmd = &cobra.Command{
+	Use:   "validate [arquivo...]",
+	Short: common.T("Valida arquivos de laboratório contra o schema", "Valida archivos de laboratorio contra el schema"),
+	Long: common.T(`Valida templates de laboratório, ConfigMaps ou lab.yaml puros, contra o schema
+versionado dos laboratórios (`+labschema.Version+`). Cada problema é informado com a linha e a
+coluna no arquivo, inclusive dentro do bloco lab.yaml: tarefas ausentes,
+validações vazias, tipos de dica desconhecidos, durações inválidas etc.
+
+O JSON Schema correspondente pode ser obtido com 'girus lab schema'.`,
+		`Valida plantillas de laboratorio, ConfigMaps o lab.yaml puros, contra el schema
+versionado de los laboratorios (`+labschema.Version+`). Cada problema se informa con la línea y la
+columna en el archivo, incluso dentro del bloque lab.yaml: tareas ausentes,
+validaciones vacías, tipos de consejo desconocidos, duraciones inválidas etc.
+
+El JSON Schema correspondiente se obtiene con 'girus lab schema'.`),
+	Example: `  girus lab validate labs/linux_comandos-basicos/lab.yaml
+  girus lab validate internal/templates/manifests/lab_*.yaml -o json`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var results []labValidation
+		invalid := 0
+		for _, file := range args {
+			result := labValidation{File: file, Issues: []labschema.Issue{}}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				result.Issues = append(result.Issues, labschema.Issue{Message: err.Error()})
+			} else if _, issues := labschema.Validate(data); issues != nil {
+				result.Issues = issues
+			}
+			result.Valid = len(result.Issues) == 0
+			if !result.Valid {
+				invalid++
+			}
+			results = append(results, result)
+		}
+
+		if structuredOutput() {
+			exitOnOutputError(printStructured(results))
+		} else {
+			for _, result := range results {
+				if result.Valid {
+					fmt.Printf("%s %s\n", green("✓"), result.File)
+					continue
+				}
+				for _, issue := range result.Issues {
+					if issue.Line == 0 {
+						fmt.Printf("%s %s: %s\n", red("✗"), result.File, issue.Message)
+						continue
+					}
+					fmt.Printf("%s %s:%s\n", red("✗"), result.File, issue)
+				}
+			}
+			if invalid > 0 {
+				fmt.Printf(common.T("\n%s %d de %d arquivos com problemas\n", "\n%s %d de %d archivos con problemas\n"), red(common.T("ERRO:", "ERROR:")), invalid, len(results))
+			}
+		}
+
+		if invalid > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var labSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: common.T("Exibe o JSON Schema dos laboratórios", "Muestra el JSON Schema de los laboratorios"),
+	Long: common.T(`Exibe o JSON Schema do documento lab.yaml, para uso em editores e na CI.`,
+		`Muestra el JSON Schema del documento lab.yaml, para usar en editores y en la CI.`),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Stdout.Write(labschema.JSONSchema)
+	},
+}
+
+func init() {
+	labCmd.AddCommand(labValidateCmd, labSchemaCmd)
+}
@@ -1,16 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/badtuxx/girus-cli/internal/cluster"
 	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/k8s"
 	"github.com/badtuxx/girus-cli/internal/repo"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 var (
@@ -27,73 +31,130 @@ var listCmd = &cobra.Command{
 
 var listClustersCmd = &cobra.Command{
 	Use:   "clusters",
-	Short: common.T("Lista os clusters Kind disponíveis", "Lista los clusters Kind disponibles"),
-	Long:  common.T("Lista todos os clusters Kind disponíveis no sistema, destacando os que executam o Girus.", "Lista todos los clusters Kind disponibles en el sistema, destacando los que ejecutan Girus."),
+	Short: common.T("Lista os clusters locais disponíveis", "Lista los clusters locales disponibles"),
+	Long:  common.T("Lista todos os clusters do provedor selecionado (--provider), destacando os que executam o Girus.", "Lista todos los clusters del proveedor seleccionado (--provider), destacando los que ejecutan Girus."),
 	Run: func(cmd *cobra.Command, args []string) {
+		if !structuredOutput() {
+			fmt.Println(headerColor(common.T("CLUSTERS", "CLUSTERS")))
+			fmt.Println(strings.Repeat("─", 80))
+			fmt.Println(common.T("Obtendo lista de clusters...", "Obteniendo lista de clusters..."))
+		}
 
-		fmt.Println(headerColor(common.T("CLUSTERS KIND", "CLUSTERS KIND")))
-		fmt.Println(strings.Repeat("─", 80))
-		fmt.Println(common.T("Obtendo lista de clusters Kind...", "Obteniendo lista de clusters Kind..."))
+		clusterManager, err := cluster.NewProvider(common.ClusterProvider(), "", nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red("ERRO:"), err)
+			os.Exit(1)
+		}
 
-		getCmd := exec.Command("kind", "get", "clusters")
-		output, err := getCmd.Output()
+		clusters, err := clusterManager.List()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s %s: %v\n", red("ERRO:"), common.T("Erro ao obter clusters Kind", "Error al obtener clusters Kind"), err)
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", red("ERRO:"), common.T("Erro ao obter clusters", "Error al obtener clusters"), err)
 			os.Exit(1)
 		}
 
-		clusters := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if structuredOutput() {
+			exitOnOutputError(printStructured(collectClusters(clusters)))
+			return
+		}
 
-		if len(clusters) == 0 || (len(clusters) == 1 && clusters[0] == "") {
-			fmt.Println(common.T("Nenhum cluster Kind encontrado.", "Ningún cluster Kind encontrado."))
+		if len(clusters) == 0 {
+			fmt.Println(common.T("Nenhum cluster encontrado.", "Ningún cluster encontrado."))
 			return
 		}
 
-		fmt.Println("\n" + headerColor(common.T("Clusters Kind disponíveis:", "Clusters Kind disponibles:")))
+		fmt.Println("\n" + headerColor(common.T("Clusters disponíveis:", "Clusters disponibles:")))
 
-		for _, cluster := range clusters {
-			if cluster == "" {
+		for _, name := range clusters {
+			if name == "" {
 				continue
 			}
 
-			// Verificar se é um cluster Girus verificando o namespace girus
-			// Mudar contexto do kubectl para o cluster atual
-			contextCmd := exec.Command("kubectl", "config", "use-context", fmt.Sprintf("kind-%s", cluster))
-			contextCmd.Run() // Ignoramos erros aqui, pois vamos verificar no próximo comando
-
-			// Verificar se o namespace girus existe
-			checkCmd := exec.Command("kubectl", "get", "namespace", "girus", "--no-headers", "--ignore-not-found")
-			checkOutput, _ := checkCmd.Output()
-
-			isGirus := strings.Contains(string(checkOutput), "girus")
-
-			if isGirus {
-				fmt.Printf("%s Cluster %s (%s)\n", green(common.T("ATIVO", "ACTIVO")), magenta(cluster), "cluster Girus")
+			// Consultar cada cluster pelo seu próprio contexto, sem alterar o current-context
+			kubeContext := common.KubeContextFor(name)
+			info, err := clusterInfo(kubeContext)
+			if err != nil {
+				fmt.Printf("%s Cluster %s (%s)\n", yellow(common.T("INACESSÍVEL", "INACCESIBLE")), magenta(name), kubeContext)
+				fmt.Printf("   └─ %v\n", err)
+				continue
+			}
 
-				// Verificar o status dos pods no namespace girus
-				podsCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-o", "custom-columns=NAME:.metadata.name,STATUS:.status.phase,READY:.status.containerStatuses[0].ready", "--no-headers")
-				podsOutput, _ := podsCmd.Output()
+			if info.GirusInstalled {
+				fmt.Printf("%s Cluster %s (%s)\n", green(common.T("ATIVO", "ACTIVO")), magenta(name), "cluster Girus")
+			} else {
+				fmt.Printf("%s Cluster %s (%s)\n", red(common.T("INATIVO", "INACTIVO")), magenta(name), common.T("cluster não-Girus", "cluster no-Girus"))
+			}
 
-				if len(podsOutput) > 0 {
-					fmt.Println("   " + cyan(common.T("Pods:", "Pods:")))
-					podLines := strings.Split(strings.TrimSpace(string(podsOutput)), "\n")
-					for _, podLine := range podLines {
-						if podLine != "" {
-							fmt.Printf("   └─ %s\n", podLine)
-						}
-					}
+			girusVersion := "-"
+			if info.GirusInstalled {
+				girusVersion = info.BackendVersion()
+			}
+			age := "-"
+			if !info.Created.IsZero() {
+				age = duration.HumanDuration(time.Since(info.Created))
+			}
+			fmt.Printf("   %s %d   %s %s   %s %s   %s %s\n",
+				cyan(common.T("Nós:", "Nodos:")), info.Nodes,
+				cyan("Kubernetes:"), info.KubernetesVersion,
+				cyan("Girus:"), girusVersion,
+				cyan(common.T("Idade:", "Edad:")), age)
+
+			if len(info.Pods) > 0 {
+				fmt.Println("   " + cyan(common.T("Pods:", "Pods:")))
+				for _, pod := range info.Pods {
+					fmt.Printf("   └─ %s %s %t\n", pod.Name, pod.Phase, pod.Ready)
 				}
-			} else {
-				fmt.Printf("%s Cluster %s (%s)\n", red(common.T("INATIVO", "INACTIVO")), magenta(cluster), "cluster não-Girus")
 			}
 		}
 	},
 }
 
+// clusterOutput é a saída estruturada de 'girus list clusters' (-o json|yaml)
+type clusterOutput struct {
+	Name         string `json:"name"`
+	Context      string `json:"context"`
+	GirusVersion string `json:"girusVersion,omitempty"`
+	Error        string `json:"error,omitempty"`
+	*k8s.ClusterInfo
+}
+
+// collectClusters consulta cada cluster, registrando o erro dos inacessíveis
+func collectClusters(clusters []string) []clusterOutput {
+	result := []clusterOutput{}
+	for _, name := range clusters {
+		if name == "" {
+			continue
+		}
+		entry := clusterOutput{Name: name, Context: common.KubeContextFor(name)}
+		info, err := clusterInfo(entry.Context)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.ClusterInfo = info
+			if info.GirusInstalled {
+				entry.GirusVersion = info.BackendVersion()
+			}
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// clusterInfo consulta um cluster pelo contexto informado do kubeconfig
+func clusterInfo(kubeContext string) (*k8s.ClusterInfo, error) {
+	client, err := k8s.NewKubernetesClientForContext(kubeContext, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	return client.ClusterInfo(ctx)
+}
+
 // Para compatibilidade, mantemos o comando singular, mas ele chamará o plural
 var listClusterCmd = &cobra.Command{
 	Use:    "cluster",
-	Short:  common.T("Lista os clusters Kind disponíveis (alias para 'clusters')", "Lista los clusters Kind disponibles (alias de 'clusters')"),
+	Short:  common.T("Lista os clusters locais disponíveis (alias para 'clusters')", "Lista los clusters locales disponibles (alias de 'clusters')"),
 	Hidden: true,
 	Run: func(cmd *cobra.Command, args []string) {
 		listClustersCmd.Run(cmd, args)
@@ -125,12 +186,14 @@ var listLabsCmd = &cobra.Command{
 		headerColor := color.New(color.FgCyan, color.Bold).SprintFunc()
 		bold := color.New(color.Bold).SprintFunc()
 
-		fmt.Println(headerColor(common.T("LABORATÓRIOS DISPONÍVEIS", "LABORATORIOS DISPONIBLES")))
-		fmt.Println(strings.Repeat("─", 80))
-		fmt.Println(common.T("Obtendo lista de laboratórios do Girus...", "Obteniendo lista de laboratorios de Girus..."))
+		if !structuredOutput() {
+			fmt.Println(headerColor(common.T("LABORATÓRIOS DISPONÍVEIS", "LABORATORIOS DISPONIBLES")))
+			fmt.Println(strings.Repeat("─", 80))
+			fmt.Println(common.T("Obtendo lista de laboratórios do Girus...", "Obteniendo lista de laboratorios de Girus..."))
+		}
 
 		// Verificar se há um cluster Girus ativo
-		checkCmd := exec.Command("kubectl", "get", "namespace", "girus", "--no-headers", "--ignore-not-found")
+		checkCmd := k8s.KubectlCommand("get", "namespace", "girus", "--no-headers", "--ignore-not-found")
 		checkOutput, err := checkCmd.Output()
 		if err != nil || !strings.Contains(string(checkOutput), "girus") {
 			fmt.Fprintf(os.Stderr, "%s %s\n", red("ERRO:"), common.T("Nenhum cluster Girus ativo encontrado", "Ningún cluster Girus activo encontrado"))
@@ -139,7 +202,7 @@ var listLabsCmd = &cobra.Command{
 		}
 
 		// Verificar o pod do backend
-		backendCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.phase}")
+		backendCmd := k8s.KubectlCommand("get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.phase}")
 		backendOutput, err := backendCmd.Output()
 		if err != nil || string(backendOutput) != "Running" {
 			fmt.Fprintf(os.Stderr, "%s %s\n", red("ERRO:"), common.T("O backend do Girus não está em execução", "El backend de Girus no está en ejecución"))
@@ -148,7 +211,7 @@ var listLabsCmd = &cobra.Command{
 		}
 
 		// Fazer uma solicitação para a API para obter a lista de laboratórios
-		apiCmd := exec.Command("kubectl", "exec", "-n", "girus", "deploy/girus-backend", "--",
+		apiCmd := k8s.KubectlCommand("exec", "-n", "girus", "deploy/girus-backend", "--",
 			"wget", "-q", "-O-", "http://localhost:8080/api/v1/templates")
 		apiOutput, err := apiCmd.Output()
 
@@ -167,6 +230,14 @@ var listLabsCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if structuredOutput() {
+			if response.Templates == nil {
+				response.Templates = []LabTemplate{}
+			}
+			exitOnOutputError(printStructured(response.Templates))
+			return
+		}
+
 		// Exibir a lista de laboratórios
 		if len(response.Templates) == 0 {
 			fmt.Printf("\n%s %s\n", yellow("AVISO:"), common.T("Nenhum laboratório disponível.", "Ningún laboratorio disponible."))
@@ -207,9 +278,11 @@ var listRepoLabsCmd = &cobra.Command{
 		headerColor := color.New(color.FgCyan, color.Bold).SprintFunc()
 		bold := color.New(color.Bold).SprintFunc()
 
-		fmt.Println(headerColor(common.T("LABORATÓRIOS DO REPOSITÓRIO", "LABORATORIOS DEL REPOSITORIO")))
-		fmt.Println(strings.Repeat("─", 80))
-		fmt.Println(common.T("Buscando laboratórios no repositório remoto...", "Buscando laboratorios en el repositorio remoto..."))
+		if !structuredOutput() {
+			fmt.Println(headerColor(common.T("LABORATÓRIOS DO REPOSITÓRIO", "LABORATORIOS DEL REPOSITORIO")))
+			fmt.Println(strings.Repeat("─", 80))
+			fmt.Println(common.T("Buscando laboratórios no repositório remoto...", "Buscando laboratorios en el repositorio remoto..."))
+		}
 
 		// Obter o index.yaml
 		index, err := repo.GetLabsIndex(listRepoIndexURL)
@@ -218,6 +291,14 @@ var listRepoLabsCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if structuredOutput() {
+			if index.Labs == nil {
+				index.Labs = []repo.Lab{}
+			}
+			exitOnOutputError(printStructured(index.Labs))
+			return
+		}
+
 		if len(index.Labs) == 0 {
 			fmt.Printf("\n%s %s\n", yellow("AVISO:"), common.T("Nenhum laboratório disponível no repositório.", "Ningún laboratorio disponible en el repositorio."))
 			return
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/report"
+	"sigs.k8s.io/yaml"
+)
+
+// Formatos aceitos pela flag global -o/--output
+const (
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// outputFormat é o formato da saída dos comandos de consulta (flag global -o);
+// vazio mantém as tabelas coloridas
+var outputFormat string
+
+// progressMode é o modo de exibição do progresso das operações longas (flag
+// global --progress)
+var progressMode string
+
+// validateProgressMode recusa modos desconhecidos em --progress
+func validateProgressMode() error {
+	if report.ValidMode(progressMode) {
+		return nil
+	}
+	return fmt.Errorf(common.T("modo de progresso inválido %q: use %s", "modo de progreso inválido %q: use %s"), progressMode, strings.Join(report.Modes, ", "))
+}
+
+// newReporter cria o Reporter escolhido em --progress. Com --verbose e o modo
+// auto, cada evento vira uma linha de log, sem o spinner.
+func newReporter(verbose bool) report.Reporter {
+	if verbose && progressMode == report.ModeAuto {
+		return report.New(report.ModePlain)
+	}
+	return report.New(progressMode)
+}
+
+// validateOutputFormat recusa formatos desconhecidos em -o
+func validateOutputFormat() error {
+	switch outputFormat {
+	case "", outputJSON, outputYAML:
+		return nil
+	}
+	return fmt.Errorf(common.T("formato de saída inválido %q: use json ou yaml", "formato de salida inválido %q: use json o yaml"), outputFormat)
+}
+
+// structuredOutput informa se o comando deve emitir JSON ou YAML em vez das tabelas
+func structuredOutput() bool {
+	return outputFormat != ""
+}
+
+// printStructured escreve v no stdout no formato escolhido em -o. Os campos
+// seguem as tags json dos tipos, também usadas na conversão para YAML.
+func printStructured(v any) error {
+	if outputFormat == outputYAML {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("falha ao serializar a saída em YAML: %w", err)
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("falha ao serializar a saída em JSON: %w", err)
+	}
+	return nil
+}
+
+// exitOnOutputError encerra o comando quando a saída estruturada falha
+func exitOnOutputError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+		os.Exit(1)
+	}
+}
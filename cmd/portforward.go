@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/helpers"
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portForwardAddress    string
+	portForwardBackground bool
+	portForwardStop       bool
+	portForwardDaemon     bool
+	portForwardKubeconfig string
+	portForwardContext    string
+	frontendPort          int
+	backendPort           int
+)
+
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward",
+	Short: common.T("Encaminha o frontend e o backend do Girus para o host", "Redirige el frontend y el backend de Girus al host"),
+	Long: common.T(`Encaminha localhost:8000 para o frontend e localhost:8080 para o backend do Girus (padrão),
+reconectando automaticamente quando os pods são reiniciados. O processo fica registrado em
+~/.girus/port-forward-<contexto>.pid, usado por 'girus status' e 'girus stop'.
+
+As portas escolhidas na criação do cluster são reutilizadas; --frontend-port e
+--backend-port as substituem, e portas ocupadas são trocadas pela próxima porta livre.
+
+Sem flags, o comando roda em primeiro plano até receber Ctrl+C; com --background, roda
+em segundo plano com a saída em ~/.girus/port-forward-<contexto>.log.`,
+		`Redirige localhost:8000 al frontend y localhost:8080 al backend de Girus (predeterminado),
+reconectando automáticamente cuando los pods se reinician. El proceso queda registrado en
+~/.girus/port-forward-<contexto>.pid, usado por 'girus status' y 'girus stop'.
+
+Los puertos elegidos al crear el cluster se reutilizan; --frontend-port y
+--backend-port los reemplazan, y los puertos ocupados se cambian por el siguiente puerto libre.
+
+Sin flags, el comando se ejecuta en primer plano hasta recibir Ctrl+C; con --background, se
+ejecuta en segundo plano con la salida en ~/.girus/port-forward-<contexto>.log.`),
+	Example: `  girus port-forward
+  girus port-forward --background
+  girus port-forward --stop`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if portForwardKubeconfig != "" || portForwardContext != "" {
+			common.SetExternalCluster(portForwardKubeconfig, portForwardContext)
+		}
+
+		switch {
+		case portForwardStop:
+			pid, err := k8s.StopPortForward()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+			if pid == 0 {
+				fmt.Println(common.T("Nenhum port-forward em execução.", "Ningún port-forward en ejecución."))
+				return
+			}
+			fmt.Printf(common.T("%s Port-forward encerrado (PID %d).\n", "%s Port-forward detenido (PID %d).\n"), green(common.T("SUCESSO:", "ÉXITO:")), pid)
+		case portForwardBackground:
+			if pid, running := k8s.RunningPortForward(); running {
+				fmt.Printf(common.T("%s Port-forward já em execução (PID %d).\n", "%s Port-forward ya en ejecución (PID %d).\n"), yellow("AVISO:"), pid)
+				return
+			}
+			ports, err := choosePortForwardPorts(cmd)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+			pid, err := k8s.StartPortForwardDaemon(portForwardAddress, ports)
+			if err == nil {
+				// As portas só são gravadas depois que o processo registrou o PID
+				err = common.SavePorts(ports)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+			fmt.Printf(common.T("%s Port-forward em segundo plano (PID %d).\n", "%s Port-forward en segundo plano (PID %d).\n"), green(common.T("SUCESSO:", "ÉXITO:")), pid)
+			for _, forward := range k8s.GirusPortForwards(ports) {
+				fmt.Printf("   %s\n", magenta(forward.String()))
+			}
+			fmt.Println(common.T("Para encerrar: girus port-forward --stop", "Para detener: girus port-forward --stop"))
+		default:
+			if pid, running := k8s.RunningPortForward(); running {
+				fmt.Printf(common.T("%s Port-forward já em execução (PID %d).\n", "%s Port-forward ya en ejecución (PID %d).\n"), yellow("AVISO:"), pid)
+				return
+			}
+			if err := runPortForward(cmd); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+// runPortForward mantém os encaminhamentos no processo atual, registrado no
+// arquivo de PID, até receber um sinal de término
+func runPortForward(cmd *cobra.Command) error {
+	forwarder, err := k8s.NewPortForwarder(common.KubeContext(), "girus", portForwardAddress)
+	if err != nil {
+		return err
+	}
+
+	// O processo em segundo plano recebe as portas já escolhidas pelo que o iniciou
+	ports := common.HostPorts{Frontend: frontendPort, Backend: backendPort}
+	if !portForwardDaemon {
+		if ports, err = choosePortForwardPorts(cmd); err != nil {
+			return err
+		}
+	}
+	if err := k8s.WritePortForwardPID(); err != nil {
+		return err
+	}
+	defer k8s.RemovePortForwardPID()
+	if !portForwardDaemon {
+		// Com o PID registrado, as portas escolhidas passam a ser as do cluster
+		if err := common.SavePorts(ports); err != nil {
+			return err
+		}
+	}
+
+	signals := []os.Signal{os.Interrupt, syscall.SIGTERM}
+	if portForwardDaemon {
+		// Em segundo plano, o processo só é encerrado por 'girus stop' ou --stop,
+		// e não pelo Ctrl+C ou fechamento do terminal que o iniciou
+		signal.Ignore(syscall.SIGHUP, os.Interrupt)
+		signals = []os.Signal{syscall.SIGTERM}
+	} else {
+		fmt.Println(common.T("Encaminhando portas (Ctrl+C para encerrar):", "Redirigiendo puertos (Ctrl+C para detener):"))
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), signals...)
+	defer stop()
+
+	return forwarder.Run(ctx, k8s.GirusPortForwards(ports), func(event k8s.PortForwardEvent) {
+		timestamp := time.Now().Format("15:04:05")
+		if event.Err != nil {
+			fmt.Printf("%s %s %s: %v (%s)\n", timestamp, yellow(common.T("RECONECTANDO", "RECONECTANDO")), event.Forward, event.Err, common.T("nova tentativa em instantes", "nuevo intento en instantes"))
+			return
+		}
+		fmt.Printf("%s %s %s (pod %s)\n", timestamp, green("OK"), magenta(event.Forward.String()), event.Pod)
+	})
+}
+
+// choosePortForwardPorts parte das portas gravadas para o cluster (ou das
+// flags) e troca as ocupadas por portas livres. As portas escolhidas só são
+// gravadas depois que o port-forward registra o PID.
+func choosePortForwardPorts(cmd *cobra.Command) (common.HostPorts, error) {
+	requested := requestedPorts(cmd, common.Ports())
+	ports, err := helpers.ChoosePorts(requested)
+	if err != nil {
+		return common.HostPorts{}, err
+	}
+	warnChangedPorts(requested, ports)
+	return ports, nil
+}
+
+// requestedPorts aplica --frontend-port e --backend-port sobre as portas base
+func requestedPorts(cmd *cobra.Command, base common.HostPorts) common.HostPorts {
+	if cmd.Flags().Changed("frontend-port") {
+		base.Frontend = frontendPort
+	}
+	if cmd.Flags().Changed("backend-port") {
+		base.Backend = backendPort
+	}
+	return base
+}
+
+// warnChangedPorts avisa quando uma porta pedida estava ocupada e foi trocada
+func warnChangedPorts(requested, chosen common.HostPorts) {
+	if requested.Frontend != chosen.Frontend {
+		fmt.Printf(common.T("%s porta %d do frontend em uso; usando %d\n", "%s puerto %d del frontend en uso; usando %d\n"), yellow("AVISO:"), requested.Frontend, chosen.Frontend)
+	}
+	if requested.Backend != chosen.Backend {
+		fmt.Printf(common.T("%s porta %d do backend em uso; usando %d\n", "%s puerto %d del backend en uso; usando %d\n"), yellow("AVISO:"), requested.Backend, chosen.Backend)
+	}
+}
+
+// addPortFlags registra --frontend-port e --backend-port
+func addPortFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&frontendPort, "frontend-port", common.DefaultFrontendPort, common.T("Porta do host para o frontend (ocupada: usa a próxima livre)", "Puerto del host para el frontend (ocupado: usa el siguiente libre)"))
+	cmd.Flags().IntVar(&backendPort, "backend-port", common.DefaultBackendPort, common.T("Porta do host para o backend (ocupada: usa a próxima livre)", "Puerto del host para el backend (ocupado: usa el siguiente libre)"))
+}
+
+func init() {
+	addPortFlags(portForwardCmd)
+	portForwardCmd.Flags().StringVar(&portForwardAddress, "address", "0.0.0.0", common.T("Endereço local onde as portas são abertas", "Dirección local donde se abren los puertos"))
+	portForwardCmd.Flags().BoolVarP(&portForwardBackground, "background", "d", false, common.T("Executa em segundo plano", "Se ejecuta en segundo plano"))
+	portForwardCmd.Flags().BoolVar(&portForwardStop, "stop", false, common.T("Encerra o port-forward em execução", "Detiene el port-forward en ejecución"))
+	portForwardCmd.Flags().StringVar(&portForwardKubeconfig, "kubeconfig", "", common.T("caminho do kubeconfig (padrão: $KUBECONFIG ou ~/.kube/config)", "ruta del kubeconfig (predeterminado: $KUBECONFIG o ~/.kube/config)"))
+	portForwardCmd.Flags().StringVar(&portForwardContext, "context", "", common.T("contexto do kubeconfig (padrão: o do cluster selecionado por --cluster)", "contexto del kubeconfig (predeterminado: el del cluster seleccionado por --cluster)"))
+	// --daemon é usado pelo processo iniciado por --background
+	portForwardCmd.Flags().BoolVar(&portForwardDaemon, "daemon", false, "")
+	portForwardCmd.Flags().MarkHidden("daemon")
+}
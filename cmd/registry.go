@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/spf13/cobra"
+)
+
+var registryContainerEngine string
+
+var registryCmd = &cobra.Command{
+	Use:   "registry [subcommand]",
+	Short: common.T("Comandos para o registry local do Girus", "Comandos para el registry local de Girus"),
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var registryPushCmd = &cobra.Command{
+	Use:   "push [imagem]",
+	Short: common.T("Envia uma imagem local para o registry do Girus", "Envía una imagen local al registry de Girus"),
+	Long: common.T(`Envia uma imagem do engine de containers local para o registry do Girus
+(criado com 'girus create cluster --with-registry'), para uso em laboratórios personalizados.
+A referência exibida ao final deve ser usada no campo image do laboratório.`,
+		`Envía una imagen del engine de contenedores local al registry de Girus
+(creado con 'girus create cluster --with-registry'), para usar en laboratorios personalizados.
+La referencia mostrada al final debe usarse en el campo image del laboratorio.`),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		registry := cluster.NewRegistry(registryContainerEngine)
+		if err := registry.Ensure(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		fmt.Printf(common.T("Enviando %s para %s...\n", "Enviando %s a %s...\n"), magenta(args[0]), cluster.RegistryHost)
+		target, err := registry.Push(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			if output := cluster.CommandOutput(err); output != "" {
+				fmt.Println("   Detalhes técnicos:", output)
+			}
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s %s %s\n", green(common.T("SUCESSO:", "ÉXITO:")), common.T("Imagem disponível como", "Imagen disponible como"), magenta(target))
+		fmt.Println(common.T("Use no laboratório:", "Use en el laboratorio:"))
+		fmt.Printf("   image: \"%s\"\n", target)
+	},
+}
+
+func init() {
+	registryCmd.AddCommand(registryPushCmd)
+
+	registryPushCmd.Flags().StringVarP(&registryContainerEngine, "container-engine", "e", "docker", common.T("Engine de container (docker ou podman)", "Engine de contenedores (docker o podman)"))
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var renderOutDir string
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: common.T("Exibe a configuração do cluster e os manifestos sem aplicá-los", "Muestra la configuración del cluster y los manifiestos sin aplicarlos"),
+	Long: common.T(`Gera exatamente o que 'girus create cluster' usaria, sem criar nada: a configuração
+efetiva do Kind (ou a linha de comando do k3d/minikube) e todos os manifestos que seriam
+aplicados, já no idioma selecionado e com as opções de topologia e --file aplicadas.
+A saída vai para o stdout ou, com --out, para um diretório.`,
+		`Genera exactamente lo que 'girus create cluster' usaría, sin crear nada: la configuración
+efectiva de Kind (o la línea de comandos de k3d/minikube) y todos los manifiestos que se
+aplicarían, ya en el idioma seleccionado y con las opciones de topología y --file aplicadas.
+La salida va al stdout o, con --out, a un directorio.`),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := renderCluster(cmd, renderOutDir); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+	},
+}
+
+// renderCluster gera a configuração do cluster e os manifestos do Girus a
+// partir das flags de topologia de cmd. Com outDir vazio, tudo é escrito no
+// stdout como um único stream YAML; caso contrário, um arquivo por item.
+func renderCluster(cmd *cobra.Command, outDir string) error {
+	topology := clusterTopology(cmd)
+	clusterConfig, configName, err := cluster.Render(common.ClusterProvider(), common.ClusterName(), containerEngine, topology)
+	if err != nil {
+		return err
+	}
+
+	manifests, err := deployManifests()
+	if err != nil {
+		return err
+	}
+	if topology.Expose != cluster.ExposePortForward {
+		expose, err := exposeManifest(manifests, topology.Expose)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, expose)
+	}
+
+	if outDir == "" {
+		return writeRendered(os.Stdout, configName, clusterConfig, manifests)
+	}
+
+	manifestsDir := filepath.Join(outDir, "manifests")
+	if err := os.MkdirAll(manifestsDir, 0755); err != nil {
+		return fmt.Errorf("falha ao criar o diretório %s: %w", manifestsDir, err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, configName), clusterConfig, 0644); err != nil {
+		return fmt.Errorf("falha ao escrever %s: %w", configName, err)
+	}
+	for _, manifest := range manifests {
+		if err := os.WriteFile(filepath.Join(manifestsDir, manifest.Name), manifest.Content, 0644); err != nil {
+			return fmt.Errorf("falha ao escrever %s: %w", manifest.Name, err)
+		}
+	}
+
+	fmt.Printf(common.T("%s %s e %d manifestos gravados em %s\n", "%s %s y %d manifiestos guardados en %s\n"), green(common.T("SUCESSO:", "ÉXITO:")), configName, len(manifests), magenta(outDir))
+	return nil
+}
+
+// exposeManifest gera os objetos alterados ou acrescentados pelo modo de
+// acesso (serviços NodePort ou Ingress), aplicados sobre os manifestos
+func exposeManifest(manifests []deployManifest, mode string) (deployManifest, error) {
+	var objects []*unstructured.Unstructured
+	for _, manifest := range manifests {
+		parsed, err := k8s.ParseManifest(manifest.Content)
+		if err != nil {
+			return deployManifest{}, fmt.Errorf("%s: %w", manifest.Name, err)
+		}
+		objects = append(objects, parsed...)
+	}
+	objects, err := k8s.ExposeObjects(objects, mode, common.ClusterProvider())
+	if err != nil {
+		return deployManifest{}, err
+	}
+
+	var content bytes.Buffer
+	if mode == cluster.ExposeIngress && common.ClusterProvider() != cluster.ProviderK3d {
+		fmt.Fprintf(&content, "# ingress controller: %s\n", k8s.IngressNginxManifestURL)
+	}
+	for _, obj := range objects {
+		serviceType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+		if obj.GetKind() != "Ingress" && !(obj.GetKind() == "Service" && serviceType == "NodePort") {
+			continue
+		}
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return deployManifest{}, fmt.Errorf("falha ao serializar %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if content.Len() > 0 {
+			content.WriteString("---\n")
+		}
+		content.Write(data)
+	}
+	return deployManifest{Name: "expose-" + mode + ".yaml", Content: content.Bytes()}, nil
+}
+
+// writeRendered escreve a configuração do cluster e os manifestos como
+// documentos YAML separados, cada um precedido de um comentário com sua origem
+func writeRendered(w io.Writer, configName string, clusterConfig []byte, manifests []deployManifest) error {
+	if _, err := fmt.Fprintf(w, "# Source: %s\n", configName); err != nil {
+		return err
+	}
+	if configName != "kind-config.yaml" {
+		// A linha de comando do k3d/minikube vira um comentário para manter o YAML válido
+		if _, err := fmt.Fprintf(w, "# %s", clusterConfig); err != nil {
+			return err
+		}
+	} else if _, err := w.Write(clusterConfig); err != nil {
+		return err
+	}
+
+	for _, manifest := range manifests {
+		if _, err := fmt.Fprintf(w, "---\n# Source: %s\n", manifest.Name); err != nil {
+			return err
+		}
+		content := manifest.Content
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			content = append(content, '\n')
+		}
+		if _, err := w.Write(content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	addTopologyFlags(renderCmd)
+	renderCmd.Flags().StringVarP(&deployFile, "file", "f", "", common.T("Arquivo YAML para deployment do Girus (opcional)", "Archivo YAML para el deployment de Girus (opcional)"))
+	renderCmd.Flags().StringVarP(&containerEngine, "container-engine", "e", "docker", common.T("Engine de container (docker ou podman)", "Engine de contenedores (docker o podman)"))
+	renderCmd.Flags().StringVar(&renderOutDir, "out", "", common.T("Diretório onde gravar os arquivos em vez do stdout", "Directorio donde guardar los archivos en lugar del stdout"))
+}
@@ -73,6 +73,12 @@ var repoListCmd = &cobra.Command{
 		}
 
 		repos := rm.ListRepositories()
+		if structuredOutput() {
+			if repos == nil {
+				repos = []repo.Repository{}
+			}
+			return printStructured(repos)
+		}
 		if len(repos) == 0 {
 			fmt.Println(common.T("Nenhum repositório configurado.", "Ningún repositorio configurado."))
 			return nil
@@ -2,10 +2,24 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/report"
+)
+
+var (
+	// clusterName é o cluster Girus sobre o qual os comandos atuam (flag global --cluster)
+	clusterName string
+	// clusterProvider é o backend dos clusters locais (flag global --provider)
+	clusterProvider string
+	// assumeYes aceita as confirmações sem perguntar (flag global --yes)
+	assumeYes bool
+	// nonInteractive não pergunta nada, usando as respostas padrão (flag global --non-interactive)
+	nonInteractive bool
 )
 
 var rootCmd = &cobra.Command{
@@ -19,6 +33,23 @@ SRE, Dev e Platform Engineering.`,
 gestionar y ejecutar entornos de aprendizaje práctico para tecnologías como Linux,
 Docker, Kubernetes, Terraform y otras herramientas esenciales para profesionales de DevOps,
 SRE, Dev y Platform Engineering.`),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.SetClusterName(clusterName)
+		// Sem --provider, o cluster usa o backend com que foi criado
+		if cmd.Flags().Changed("provider") {
+			common.SetClusterProvider(clusterProvider)
+		} else {
+			common.UseSavedClusterProvider()
+		}
+		common.SetAssumeYes(assumeYes)
+		common.SetNonInteractive(nonInteractive)
+		for _, validate := range []func() error{validateOutputFormat, validateProgressMode} {
+			if err := validate(); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+				os.Exit(1)
+			}
+		}
+	},
 }
 
 // Execute executa o comando raiz
@@ -99,9 +130,24 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(stopCmd)
 	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(uninstallCmd)
+	rootCmd.AddCommand(bundleCmd)
+	rootCmd.AddCommand(registryCmd)
+	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(portForwardCmd)
 
 	// Não adicionar updateCmd aqui, pois já é adicionado no update.go
 
 	// Configura flags globais
+	rootCmd.PersistentFlags().StringVar(&clusterName, "cluster", common.ClusterName(), common.T("nome do cluster Girus (padrão definido em cluster.name no arquivo de configuração)", "nombre del cluster Girus (predeterminado en cluster.name del archivo de configuración)"))
+	rootCmd.PersistentFlags().StringVar(&clusterProvider, "provider", common.ClusterProvider(), common.T("ferramenta que gerencia o cluster local: kind, k3d ou minikube (padrão: o usado na criação do cluster ou cluster.provider no arquivo de configuração)", "herramienta que gestiona el cluster local: kind, k3d o minikube (predeterminado: el usado al crear el cluster o cluster.provider del archivo de configuración)"))
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, common.T("responde sim a todas as confirmações, inclusive as destrutivas, para uso em scripts e CI", "responde sí a todas las confirmaciones, incluso las destructivas, para uso en scripts y CI"))
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, common.T("não faz perguntas e usa a resposta padrão de cada confirmação (também: GIRUS_NONINTERACTIVE=1)", "no hace preguntas y usa la respuesta predeterminada de cada confirmación (también: GIRUS_NONINTERACTIVE=1)"))
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", common.T("formato da saída dos comandos de consulta (status, list, lab list/search, repo list): json ou yaml", "formato de salida de los comandos de consulta (status, list, lab list/search, repo list): json o yaml"))
+	rootCmd.PersistentFlags().StringVar(&progressMode, "progress", report.ModeAuto, common.T("exibição do progresso das operações longas: auto, tty (spinner), plain (linhas de log) ou json (eventos no stderr)", "visualización del progreso de las operaciones largas: auto, tty (spinner), plain (líneas de log) o json (eventos en el stderr)"))
 	rootCmd.PersistentFlags().StringP("config", "c", "", common.T("arquivo de configuração (padrão: $HOME/.girus/config.yaml)", "archivo de configuración (predeterminado: $HOME/.girus/config.yaml)"))
 }
@@ -48,7 +48,7 @@ var startCmd = &cobra.Command{
 		isFrontendRunning, err := client.IsPodRunning(ctx, "girus", frontendPod)
 		if isFrontendRunning {
 			fmt.Println(common.T("O pod de frontend já está em execução.", "El pod de frontend ya está en ejecución."))
-			fmt.Println(common.T("Tente abrir o browser e navegar até http://localhost:8000.", "Intente abrir el navegador y acceder a http://localhost:8000."))
+			fmt.Printf(common.T("Tente abrir o browser e navegar até %s.\n", "Intente abrir el navegador y acceder a %s.\n"), k8s.ClusterAccessURL())
 		}
 		if err != nil {
 			fmt.Println(common.T("Nenhum pod do frontend encontrado no namespace do GIRUS...", "Ningún pod de frontend encontrado en el namespace de GIRUS..."))
@@ -58,7 +58,7 @@ var startCmd = &cobra.Command{
 		isBackendRunning, err := client.IsPodRunning(ctx, "girus", backendPod)
 		if isBackendRunning {
 			fmt.Println(common.T("O pod de backend já está em execução.", "El pod de backend ya está en ejecución."))
-			fmt.Println(common.T("Tente abrir o browser e navegar até http://localhost:8000.", "Intente abrir el navegador y acceder a http://localhost:8000."))
+			fmt.Printf(common.T("Tente abrir o browser e navegar até %s.\n", "Intente abrir el navegador y acceder a %s.\n"), k8s.ClusterAccessURL())
 			fmt.Printf("%s %s\n", yellow(common.T("AVISO", "AVISO")), common.T("Cancelando.", "Cancelando."))
 			return
 		}
@@ -1,11 +1,12 @@
 package cmd
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/badtuxx/girus-cli/internal/cluster"
 	"github.com/badtuxx/girus-cli/internal/common"
-	"os/exec"
+	"github.com/badtuxx/girus-cli/internal/k8s"
 	"strconv"
 	"strings"
 	"time"
@@ -17,26 +18,48 @@ import (
 
 // Estrutura para armazenar informações sobre os serviços expostos
 type ServiceInfo struct {
-	Name      string
-	Type      string
-	ClusterIP string
-	Ports     string
-	Age       string
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	ClusterIP string `json:"clusterIP"`
+	Ports     string `json:"ports"`
+	Age       string `json:"age"`
 }
 
 // Estrutura para armazenar informações do pod
 type PodInfo struct {
-	Name     string
-	Ready    string
-	Status   string
-	Restarts string
-	Age      string
+	Name     string `json:"name"`
+	Ready    string `json:"ready"`
+	Status   string `json:"status"`
+	Restarts string `json:"restarts"`
+	Age      string `json:"age"`
 }
 
 // Estrutura para armazenar uso de recursos
 type ResourceUsage struct {
-	CPU    string
-	Memory string
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+// statusOutput é a saída estruturada de 'girus status' (-o json|yaml)
+type statusOutput struct {
+	Version     string             `json:"version"`
+	Cluster     string             `json:"cluster"`
+	Active      bool               `json:"active"`
+	Namespace   bool               `json:"namespace"`
+	Backend     string             `json:"backend,omitempty"`
+	Frontend    string             `json:"frontend,omitempty"`
+	Pods        []PodInfo          `json:"pods,omitempty"`
+	Services    []ServiceInfo      `json:"services,omitempty"`
+	PortForward *portForwardStatus `json:"portForward,omitempty"`
+	Labs        []LabTemplate      `json:"labs,omitempty"`
+	Resources   *ResourceUsage     `json:"resources,omitempty"`
+	URL         string             `json:"url,omitempty"`
+}
+
+// portForwardStatus descreve o port-forward registrado por 'girus port-forward'
+type portForwardStatus struct {
+	PID      int      `json:"pid"`
+	Forwards []string `json:"forwards"`
 }
 
 var statusCmd = &cobra.Command{
@@ -56,6 +79,11 @@ var statusCmd = &cobra.Command{
 - Uso de recursos
 - Versión de la CLI`),
 	Run: func(cmd *cobra.Command, args []string) {
+		if structuredOutput() {
+			exitOnOutputError(printStructured(collectStatus()))
+			return
+		}
+
 		// Criar formatadores de cores
 		green := color.New(color.FgGreen).SprintFunc()
 		red := color.New(color.FgRed).SprintFunc()
@@ -102,8 +130,8 @@ var statusCmd = &cobra.Command{
 		fmt.Println("\n" + headerColor(common.T("Componentes da Aplicação:", "Componentes de la Aplicación:")))
 		backendStatus, frontendStatus := checkComponentStatus()
 
-		fmt.Printf("   %s: %s\n", bold("Backend"), backendStatus)
-		fmt.Printf("   %s: %s\n", bold("Frontend"), frontendStatus)
+		fmt.Printf("   %s: %s\n", bold("Backend"), colorComponentStatus(backendStatus))
+		fmt.Printf("   %s: %s\n", bold("Frontend"), colorComponentStatus(frontendStatus))
 
 		// Obter informações sobre os pods detalhadas
 		pods := getPodDetails()
@@ -137,17 +165,11 @@ var statusCmd = &cobra.Command{
 			}
 		}
 
-		// Verificar port-forwards ativos
-		portForwards := getActivePortForwards()
-		if len(portForwards) > 0 {
+		// Verificar o port-forward registrado por 'girus port-forward'
+		if pid, running := k8s.RunningPortForward(); running {
 			fmt.Println("\n" + headerColor("Port-Forwards Ativos:"))
-			for _, pf := range portForwards {
-				parts := strings.Fields(pf)
-				if len(parts) >= 2 {
-					fmt.Printf("   %s %s\n", parts[0], magenta(strings.Join(parts[1:], " ")))
-				} else {
-					fmt.Printf("   %s\n", magenta(pf))
-				}
+			for _, forward := range k8s.GirusPortForwards(common.Ports()) {
+				fmt.Printf("   PID %d %s\n", pid, magenta(forward.String()))
 			}
 		}
 
@@ -156,12 +178,7 @@ var statusCmd = &cobra.Command{
 		if len(labs) > 0 {
 			fmt.Println("\n" + headerColor("Laboratórios Instalados:"))
 			for i, lab := range labs {
-				parts := strings.SplitN(lab, " - ", 2)
-				if len(parts) == 2 {
-					fmt.Printf("   %d. %s - %s\n", i+1, magenta(parts[0]), parts[1])
-				} else {
-					fmt.Printf("   %d. %s\n", i+1, magenta(lab))
-				}
+				fmt.Printf("   %d. %s - %s\n", i+1, magenta(lab.Name), lab.Title)
 			}
 		} else {
 			fmt.Println("\n" + headerColor("Laboratórios Instalados:") + " Nenhum")
@@ -190,27 +207,54 @@ var statusCmd = &cobra.Command{
 	},
 }
 
-// checkClusterExists verifica se o cluster Kind existe
+// collectStatus reúne as mesmas informações exibidas por 'girus status',
+// parando no primeiro componente ausente (cluster ou namespace)
+func collectStatus() statusOutput {
+	status := statusOutput{Version: common.Version, Cluster: clusterName}
+
+	status.Active, _ = checkClusterExists()
+	if !status.Active {
+		return status
+	}
+	status.Namespace = checkNamespaceExists()
+	if !status.Namespace {
+		return status
+	}
+
+	status.Backend, status.Frontend = checkComponentStatus()
+	status.Pods = getPodDetails()
+	status.Services = getServiceDetails()
+	if pid, running := k8s.RunningPortForward(); running {
+		status.PortForward = &portForwardStatus{PID: pid}
+		for _, forward := range k8s.GirusPortForwards(common.Ports()) {
+			status.PortForward.Forwards = append(status.PortForward.Forwards, forward.String())
+		}
+	}
+	status.Labs = getInstalledLabs()
+	resources := getNodeResources()
+	status.Resources = &resources
+	status.URL = getAccessURL()
+	return status
+}
+
+// checkClusterExists verifica se o cluster local existe no provedor selecionado
 func checkClusterExists() (bool, string) {
-	cmd := exec.Command("kind", "get", "clusters")
-	output, err := cmd.Output()
+	clusterManager, err := cluster.NewProvider(common.ClusterProvider(), "", nil)
 	if err != nil {
 		return false, ""
 	}
 
-	clusters := strings.Split(strings.TrimSpace(string(output)), "\n")
-	for _, cluster := range clusters {
-		if cluster == "girus" {
-			return true, cluster
-		}
+	exists, err := clusterManager.Exists(clusterName)
+	if err != nil || !exists {
+		return false, ""
 	}
 
-	return false, ""
+	return true, clusterName
 }
 
 // checkNamespaceExists verifica se o namespace girus existe
 func checkNamespaceExists() bool {
-	cmd := exec.Command("kubectl", "get", "namespace", "girus", "--no-headers", "--ignore-not-found")
+	cmd := k8s.KubectlCommand("get", "namespace", "girus", "--no-headers", "--ignore-not-found")
 	output, err := cmd.Output()
 	if err != nil {
 		return false
@@ -221,61 +265,46 @@ func checkNamespaceExists() bool {
 
 // checkComponentStatus verifica o status dos componentes backend e frontend
 func checkComponentStatus() (string, string) {
-	// Criar formatadores de cores
-	green := color.New(color.FgGreen).SprintFunc()
-	red := color.New(color.FgRed).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
+	return componentStatus("girus-backend"), componentStatus("girus-frontend")
+}
 
-	// Verificar o backend
-	backendCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.phase}")
-	backendOutput, err := backendCmd.Output()
-	var backendStatus string
-	if err == nil && len(backendOutput) > 0 {
-		status := string(backendOutput)
-		if status == "Running" {
-			// Verificar se todos os containers estão prontos
-			readyCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.containerStatuses[0].ready}")
-			readyOutput, err := readyCmd.Output()
-			if err == nil && string(readyOutput) == "true" {
-				backendStatus = green("Pronto")
-			} else {
-				backendStatus = yellow("Inicializando")
-			}
-		} else {
-			backendStatus = yellow(status)
-		}
-	} else {
-		backendStatus = red("Não encontrado")
-	}
-
-	// Verificar o frontend
-	frontendCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-l", "app=girus-frontend", "-o", "jsonpath={.items[0].status.phase}")
-	frontendOutput, err := frontendCmd.Output()
-	var frontendStatus string
-	if err == nil && len(frontendOutput) > 0 {
-		status := string(frontendOutput)
-		if status == "Running" {
-			// Verificar se todos os containers estão prontos
-			readyCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-l", "app=girus-frontend", "-o", "jsonpath={.items[0].status.containerStatuses[0].ready}")
-			readyOutput, err := readyCmd.Output()
-			if err == nil && string(readyOutput) == "true" {
-				frontendStatus = green("Pronto")
-			} else {
-				frontendStatus = yellow("Inicializando")
-			}
-		} else {
-			frontendStatus = yellow(status)
-		}
-	} else {
-		frontendStatus = red("Não encontrado")
+// componentStatus retorna o estado do primeiro pod do componente: Pronto,
+// Inicializando, a fase do pod ou Não encontrado
+func componentStatus(app string) string {
+	phaseCmd := k8s.KubectlCommand("get", "pods", "-n", "girus", "-l", "app="+app, "-o", "jsonpath={.items[0].status.phase}")
+	phaseOutput, err := phaseCmd.Output()
+	if err != nil || len(phaseOutput) == 0 {
+		return "Não encontrado"
+	}
+
+	status := string(phaseOutput)
+	if status != "Running" {
+		return status
 	}
 
-	return backendStatus, frontendStatus
+	// Verificar se todos os containers estão prontos
+	readyCmd := k8s.KubectlCommand("get", "pods", "-n", "girus", "-l", "app="+app, "-o", "jsonpath={.items[0].status.containerStatuses[0].ready}")
+	readyOutput, err := readyCmd.Output()
+	if err == nil && string(readyOutput) == "true" {
+		return "Pronto"
+	}
+	return "Inicializando"
+}
+
+// colorComponentStatus destaca o estado do componente na saída em tabela
+func colorComponentStatus(status string) string {
+	switch status {
+	case "Pronto":
+		return green(status)
+	case "Não encontrado":
+		return red(status)
+	}
+	return yellow(status)
 }
 
 // getPodDetails obtém detalhes sobre os pods
 func getPodDetails() []PodInfo {
-	cmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-o", "custom-columns=NAME:.metadata.name,READY:.status.containerStatuses[0].ready,STATUS:.status.phase,RESTARTS:.status.containerStatuses[0].restartCount,AGE:.metadata.creationTimestamp")
+	cmd := k8s.KubectlCommand("get", "pods", "-n", "girus", "-o", "custom-columns=NAME:.metadata.name,READY:.status.containerStatuses[0].ready,STATUS:.status.phase,RESTARTS:.status.containerStatuses[0].restartCount,AGE:.metadata.creationTimestamp")
 	output, err := cmd.Output()
 	if err != nil {
 		return []PodInfo{}
@@ -331,7 +360,7 @@ func getPodDetails() []PodInfo {
 
 // getServiceDetails obtém detalhes sobre os serviços
 func getServiceDetails() []ServiceInfo {
-	cmd := exec.Command("kubectl", "get", "services", "-n", "girus", "-o", "custom-columns=NAME:.metadata.name,TYPE:.spec.type,CLUSTER-IP:.spec.clusterIP,PORT:.spec.ports[*].port,AGE:.metadata.creationTimestamp")
+	cmd := k8s.KubectlCommand("get", "services", "-n", "girus", "-o", "custom-columns=NAME:.metadata.name,TYPE:.spec.type,CLUSTER-IP:.spec.clusterIP,PORT:.spec.ports[*].port,AGE:.metadata.creationTimestamp")
 	output, err := cmd.Output()
 	if err != nil {
 		return []ServiceInfo{}
@@ -352,7 +381,7 @@ func getServiceDetails() []ServiceInfo {
 		fields := strings.Fields(line)
 		if len(fields) >= 5 {
 			// Obter portas expostas
-			portsCmd := exec.Command("kubectl", "get", "service", fields[0], "-n", "girus", "-o", "jsonpath={.spec.ports[*].port}:{.spec.ports[*].nodePort}")
+			portsCmd := k8s.KubectlCommand("get", "service", fields[0], "-n", "girus", "-o", "jsonpath={.spec.ports[*].port}:{.spec.ports[*].nodePort}")
 			portsOutput, err := portsCmd.Output()
 			ports := fields[3]
 			if err == nil && len(portsOutput) > 0 {
@@ -391,71 +420,36 @@ func getServiceDetails() []ServiceInfo {
 	return services
 }
 
-// getActivePortForwards obtém os port-forwards ativos
-func getActivePortForwards() []string {
-	cmd := exec.Command("ps", "-ef")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
-	if err != nil {
-		return []string{}
-	}
-
-	var portForwards []string
-	for _, line := range strings.Split(out.String(), "\n") {
-		if strings.Contains(line, "kubectl port-forward") && strings.Contains(line, "girus") {
-			parts := strings.Fields(line)
-			if len(parts) > 8 {
-				portForwards = append(portForwards, strings.Join(parts[7:], " "))
-			}
-		}
-	}
-
-	return portForwards
-}
-
 // getInstalledLabs obtém os laboratórios instalados
-func getInstalledLabs() []string {
+func getInstalledLabs() []LabTemplate {
 	// Verificar se há um cluster Girus ativo
 	if !checkNamespaceExists() {
-		return []string{}
+		return []LabTemplate{}
 	}
 
 	// Verificar se o backend está pronto
-	backendCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.phase}")
+	backendCmd := k8s.KubectlCommand("get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.phase}")
 	backendOutput, err := backendCmd.Output()
 	if err != nil || string(backendOutput) != "Running" {
-		return []string{}
+		return []LabTemplate{}
 	}
 
 	// Fazer uma solicitação para a API para obter a lista de laboratórios
-	apiCmd := exec.Command("kubectl", "exec", "-n", "girus", "deploy/girus-backend", "--",
+	apiCmd := k8s.KubectlCommand("exec", "-n", "girus", "deploy/girus-backend", "--",
 		"wget", "-q", "-O-", "http://localhost:8080/api/v1/templates")
 	apiOutput, err := apiCmd.Output()
 
 	if err != nil {
-		return []string{}
+		return []LabTemplate{}
 	}
 
 	// Processar a resposta JSON
-	var response struct {
-		Templates []struct {
-			Name        string `json:"name"`
-			Title       string `json:"title"`
-			Description string `json:"description"`
-		} `json:"templates"`
-	}
-
+	var response LabListResponse
 	if err := json.Unmarshal(apiOutput, &response); err != nil {
-		return []string{}
+		return []LabTemplate{}
 	}
 
-	var labs []string
-	for _, template := range response.Templates {
-		labs = append(labs, fmt.Sprintf("%s - %s", template.Name, template.Title))
-	}
-
-	return labs
+	return response.Templates
 }
 
 // getNodeResources obtém informações sobre os recursos do cluster
@@ -464,7 +458,7 @@ func getNodeResources() ResourceUsage {
 	memoryUsage := "Não disponível"
 
 	// Abordagem 1: Tentar kubectl top nodes
-	topNodesCmd := exec.Command("kubectl", "top", "nodes", "--no-headers")
+	topNodesCmd := k8s.KubectlCommand("top", "nodes", "--no-headers")
 	topNodesOutput, err := topNodesCmd.Output()
 	if err == nil && len(topNodesOutput) > 0 {
 		fields := strings.Fields(string(topNodesOutput))
@@ -492,7 +486,7 @@ func getNodeResources() ResourceUsage {
 	}
 
 	// Abordagem 2: Obter recursos através dos pods
-	topPodsCmd := exec.Command("kubectl", "top", "pods", "-n", "girus", "--no-headers")
+	topPodsCmd := k8s.KubectlCommand("top", "pods", "-n", "girus", "--no-headers")
 	topPodsOutput, err := topPodsCmd.Output()
 	if err == nil && len(topPodsOutput) > 0 {
 		lines := strings.Split(strings.TrimSpace(string(topPodsOutput)), "\n")
@@ -539,7 +533,7 @@ func getNodeResources() ResourceUsage {
 	}
 
 	// Abordagem 3: Obter informações através do kubectl describe node
-	describeNodeCmd := exec.Command("kubectl", "describe", "node")
+	describeNodeCmd := k8s.KubectlCommand("describe", "node")
 	describeOutput, err := describeNodeCmd.Output()
 	if err == nil {
 		describeStr := string(describeOutput)
@@ -624,7 +618,7 @@ func getNodeResources() ResourceUsage {
 	}
 
 	// Abordagem 4: Verificar a definição do nó Kind
-	kindNodeCmd := exec.Command("kubectl", "get", "node", "-o", "jsonpath={.items[0].status.capacity}")
+	kindNodeCmd := k8s.KubectlCommand("get", "node", "-o", "jsonpath={.items[0].status.capacity}")
 	kindOutput, err := kindNodeCmd.Output()
 	if err == nil && len(kindOutput) > 0 {
 		// Parsear a saída JSON
@@ -714,30 +708,31 @@ func formatMemory(memStr string) string {
 	}
 }
 
-// getAccessURL obtém a URL de acesso à aplicação
+// getAccessURL obtém a URL de acesso à aplicação conforme o modo de acesso do cluster
 func getAccessURL() string {
-	// Verificar se o serviço frontend existe
-	frontendCmd := exec.Command("kubectl", "get", "service", "girus-frontend", "-n", "girus", "--no-headers", "--ignore-not-found")
-	_, err := frontendCmd.Output()
+	client, err := k8s.NewKubernetesClient()
 	if err != nil {
 		return "Não disponível"
 	}
 
-	// Verificar se há port-forward ativo
-	portForwards := getActivePortForwards()
-	for _, pf := range portForwards {
-		if strings.Contains(pf, "girus-frontend") && strings.Contains(pf, "8000:") {
-			return "http://localhost:8000"
-		}
+	// Verificar se o serviço frontend existe
+	frontendCmd := k8s.KubectlCommand("get", "service", "girus-frontend", "-n", "girus", "--no-headers", "--ignore-not-found")
+	output, err := frontendCmd.Output()
+	if err != nil || len(output) == 0 {
+		return "Não disponível"
 	}
 
-	// Verificar nodePort
-	nodePortCmd := exec.Command("kubectl", "get", "service", "girus-frontend", "-n", "girus", "-o", "jsonpath={.spec.ports[0].nodePort}")
-	nodePortOutput, err := nodePortCmd.Output()
-	if err == nil && len(nodePortOutput) > 0 {
-		return fmt.Sprintf("http://localhost:%s", string(nodePortOutput))
+	// Nos modos ingress e nodeport o acesso não depende de port-forward
+	mode := client.ExposeMode(context.Background())
+	if mode != cluster.ExposePortForward {
+		return fmt.Sprintf("%s (%s)", k8s.AccessURL(mode), mode)
+	}
+
+	// Verificar se há port-forward ativo
+	if _, running := k8s.RunningPortForward(); running {
+		return fmt.Sprintf("%s (%s)", k8s.AccessURL(mode), mode)
 	}
 
 	// Se não encontrou nenhuma forma de acesso
-	return "Execute 'kubectl port-forward svc/girus-frontend -n girus 8000:80' para acessar"
+	return "Execute 'girus port-forward --background' para acessar"
 }
@@ -1,13 +1,13 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/helpers"
 	"github.com/badtuxx/girus-cli/internal/k8s"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -31,13 +31,7 @@ var stopCmd = &cobra.Command{
 		fmt.Printf(common.T("%s Você está prestes a parar o %s e o %s no cluster %s.\n",
 			"%s Está a punto de detener %s y %s en el cluster %s.\n"),
 			yellow(common.T("AVISO:", "AVISO:")), magenta("frontend"), magenta("backend"), magenta(clusterName))
-		fmt.Print(common.T("Deseja continuar? [s/N]: ", "¿Desea continuar? [s/N]: "))
-
-		reader := bufio.NewReader(os.Stdin)
-		confirmStr, _ := reader.ReadString('\n')
-		confirm := strings.TrimSpace(strings.ToLower(confirmStr))
-
-		if confirm != "s" && confirm != "sim" && confirm != "y" && confirm != "yes" {
+		if !helpers.Confirm(common.T("Deseja continuar?", "¿Desea continuar?"), false) {
 			fmt.Println(common.T("Operação cancelada pelo usuário.", "Operación cancelada por el usuario."))
 			return
 		}
@@ -94,6 +88,13 @@ var stopCmd = &cobra.Command{
 		} else {
 			fmt.Println("⚠️ " + common.T("O frontend não está em execução..", "El frontend no está en ejecución."))
 		}
+
+		// Encerra o port-forward registrado por 'girus port-forward'
+		if pid, err := k8s.StopPortForward(); err != nil {
+			fmt.Printf("%s %v\n", yellow(common.T("AVISO:", "AVISO:")), err)
+		} else if pid != 0 {
+			fmt.Println("✅ " + fmt.Sprintf(common.T("Port-forward encerrado (PID %d).", "Port-forward detenido (PID %d)."), pid))
+		}
 	},
 }
 
@@ -107,13 +108,7 @@ func deleteDeployment(client *k8s.KubernetesClient, ctx context.Context, deploym
 
 		fmt.Printf(common.T("%s Você quer forçar a parada do deployment %s?\n",
 			"%s ¿Desea forzar la detención del deployment %s?\n"), yellow(common.T("AVISO:", "AVISO:")), magenta(deploymentName))
-		fmt.Print(common.T("Deseja continuar? [s/N]: ", "¿Desea continuar? [s/N]: "))
-
-		reader := bufio.NewReader(os.Stdin)
-		confirmStr, _ := reader.ReadString('\n')
-		confirm := strings.TrimSpace(strings.ToLower(confirmStr))
-
-		if confirm != "s" && confirm != "sim" && confirm != "y" && confirm != "yes" {
+		if !helpers.Confirm(common.T("Deseja continuar?", "¿Desea continuar?"), false) {
 			fmt.Println(common.T("Operação cancelada pelo usuário.", "Operación cancelada por el usuario."))
 			return err
 		}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/helpers"
 	"io"
 	"net/http"
 	"os"
@@ -26,11 +27,11 @@ var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: common.T("Atualiza o GIRUS CLI para a última versão", "Actualiza el GIRUS CLI a la última versión"),
 	Long: common.T(`Verifica e atualiza o GIRUS CLI para a última versão disponível.
-Após a atualização, oferece a opção de recriar o cluster para garantir
-compatibilidade com as novas features.`,
+Após a atualização, oferece a opção de atualizar os componentes do cluster
+com 'girus upgrade', sem recriá-lo.`,
 		`Verifica y actualiza el GIRUS CLI a la última versión disponible.
-Después de la actualización, ofrece la opción de recrear el cluster para garantizar
-compatibilidad con las nuevas funciones.`),
+Después de la actualización, ofrece la opción de actualizar los componentes del cluster
+con 'girus upgrade', sin recrearlo.`),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Criar formatadores de cores
 		green := color.New(color.FgGreen).SprintFunc()
@@ -69,11 +70,8 @@ compatibilidad con las nuevas funciones.`),
 		}
 
 		// Confirmar atualização
-		fmt.Printf("\n%s (%s). %s ",
-			yellow(common.T("Nova versão disponível", "Nueva versión disponible")), magenta(latestCliVersion), common.T("Deseja atualizar? (S/n):", "¿Desea actualizar? (S/n):"))
-		var response string
-		fmt.Scanln(&response)
-		if strings.ToLower(response) != "s" && response != "" {
+		fmt.Printf("\n%s (%s). ", yellow(common.T("Nova versão disponível", "Nueva versión disponible")), magenta(latestCliVersion))
+		if !helpers.Confirm(common.T("Deseja atualizar?", "¿Desea actualizar?"), true) {
 			fmt.Println(yellow(common.T("Atualização cancelada.", "Actualización cancelada.")))
 			return nil
 		}
@@ -86,31 +84,18 @@ compatibilidad con las nuevas funciones.`),
 		fmt.Printf("%s %s %s %s!\n",
 			green(common.T("SUCESSO:", "ÉXITO:")), common.T("CLI atualizada com sucesso para a versão", "CLI actualizada con éxito a la versión"), magenta(latestCliVersion), "")
 
-		// Perguntar se deseja recriar o cluster
-		fmt.Print("\n" + yellow(common.T("Deseja recriar o cluster para garantir compatibilidade com as novas features? (S/n): ", "¿Desea recrear el cluster para garantizar compatibilidad con las nuevas funcionalidades? (S/n): ")))
-		fmt.Scanln(&response)
-		if strings.ToLower(response) == "s" || response == "" {
-			fmt.Println("\n" + headerColor(common.T("Recriando o cluster...", "Recreando el cluster...")))
-
-			// Executar o comando delete
-			deleteCmd := exec.Command("girus", "delete")
-			deleteCmd.Stdout = os.Stdout
-			deleteCmd.Stderr = os.Stderr
-			if err := deleteCmd.Run(); err != nil {
-				return fmt.Errorf("%s %s: %v", red(common.T("ERRO:", "ERROR:")), common.T("erro ao deletar o cluster", "error al eliminar el cluster"), err)
+		// Perguntar se deseja atualizar os componentes do cluster, mantendo o progresso dos alunos
+		fmt.Println()
+		if helpers.Confirm(yellow(common.T("Deseja atualizar os componentes do Girus no cluster (girus upgrade)?", "¿Desea actualizar los componentes de Girus en el cluster (girus upgrade)?")), true) {
+			// Executar o novo binário, que contém os manifestos atualizados
+			upgradeCmd := exec.Command("girus", "upgrade")
+			upgradeCmd.Stdout = os.Stdout
+			upgradeCmd.Stderr = os.Stderr
+			if err := upgradeCmd.Run(); err != nil {
+				return fmt.Errorf("%s %s: %v", red(common.T("ERRO:", "ERROR:")), common.T("erro ao atualizar os componentes do cluster", "error al actualizar los componentes del cluster"), err)
 			}
-
-			// Executar o comando create
-			createCmd := exec.Command("girus", "create")
-			createCmd.Stdout = os.Stdout
-			createCmd.Stderr = os.Stderr
-			if err := createCmd.Run(); err != nil {
-				return fmt.Errorf("%s %s: %v", red(common.T("ERRO:", "ERROR:")), common.T("erro ao criar o cluster", "error al crear el cluster"), err)
-			}
-
-			fmt.Println("\n" + green(common.T("Cluster recriado com sucesso!", "¡Cluster recreado con éxito!")))
 		} else {
-			fmt.Println("\n" + yellow(common.T("Cluster mantido como está. Lembre-se que algumas novas features podem não funcionar corretamente com o cluster atual.", "Cluster mantenido como está. Recuerde que algunas nuevas funcionalidades pueden no funcionar correctamente con el cluster actual.")))
+			fmt.Println("\n" + yellow(common.T("Cluster mantido como está. Execute 'girus upgrade' para atualizar os componentes quando desejar.", "Cluster mantenido como está. Ejecute 'girus upgrade' para actualizar los componentes cuando lo desee.")))
 		}
 
 		return nil
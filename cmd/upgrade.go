@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	upgradeDryRun     bool
+	upgradeVerbose    bool
+	upgradeKubeconfig string
+	upgradeContext    string
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: common.T("Atualiza os componentes do Girus no cluster", "Actualiza los componentes de Girus en el cluster"),
+	Long: common.T(`Compara os deployments da plataforma e os templates de laboratório implantados
+com os embutidos nesta versão do CLI e aplica apenas os que mudaram, com rolling
+update. O cluster e o progresso dos alunos são mantidos.
+
+Para um cluster existente onde o Girus foi instalado com 'girus install',
+informe o mesmo --kubeconfig/--context usado na instalação.`,
+		`Compara los deployments de la plataforma y las plantillas de laboratorio desplegadas
+con las embebidas en esta versión del CLI y aplica solo las que cambiaron, con rolling
+update. El cluster y el progreso de los alumnos se mantienen.
+
+Para un cluster existente donde Girus se instaló con 'girus install',
+informe el mismo --kubeconfig/--context usado en la instalación.`),
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(strings.Repeat("─", 80))
+		fmt.Println(headerColor("GIRUS UPGRADE"))
+		fmt.Println(strings.Repeat("─", 80))
+
+		if upgradeKubeconfig != "" || upgradeContext != "" {
+			common.SetExternalCluster(upgradeKubeconfig, upgradeContext)
+		}
+
+		client, err := k8s.NewKubernetesClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", red(common.T("ERRO:", "ERROR:")), common.T("Erro ao criar cliente Kubernetes", "Error al crear cliente de Kubernetes"), err)
+			os.Exit(1)
+		}
+		managed, err := client.IsManagedInstall(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		embedded, err := k8s.EmbeddedObjects()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+		// Instalações feitas com 'girus install' mantêm o rótulo usado pelo 'girus uninstall'
+		candidates := k8s.UpgradeObjects(embedded, managed)
+
+		// A comparação é um server-side apply em dry-run, com o mesmo
+		// gerenciador de campos usado na aplicação
+		applier, err := k8s.NewApplier(common.KubeContext())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		// Comparar os objetos implantados com os embutidos
+		r := newReporter(upgradeVerbose)
+		compareStep := common.T("Comparando os componentes implantados", "Comparando los componentes desplegados")
+		r.Start(compareStep)
+		var changed []*unstructured.Unstructured
+		diffs := map[*unstructured.Unstructured]string{}
+		for i, obj := range candidates {
+			diff, err := applier.Diff(context.Background(), obj)
+			if err != nil {
+				r.Fail(compareStep, err)
+				os.Exit(1)
+			}
+			r.Progress(compareStep, obj.GetKind()+"/"+obj.GetName(), i+1, len(candidates))
+			if diff != "" {
+				changed = append(changed, obj)
+				diffs[obj] = diff
+			}
+		}
+		r.Done(compareStep, "")
+
+		if len(changed) == 0 {
+			fmt.Printf("%s %s\n", green(common.T("SUCESSO:", "ÉXITO:")), common.T("Todos os componentes já estão atualizados.", "Todos los componentes ya están actualizados."))
+			return
+		}
+
+		labsChanged := false
+		var deployments []string
+		for _, obj := range changed {
+			fmt.Printf("   %s %s/%s\n", yellow(common.T("ALTERADO", "MODIFICADO")), obj.GetKind(), magenta(obj.GetName()))
+			if upgradeVerbose {
+				fmt.Println(diffs[obj])
+			}
+			if obj.GetKind() == "Deployment" {
+				deployments = append(deployments, obj.GetName())
+			} else {
+				labsChanged = true
+			}
+		}
+		fmt.Printf(common.T("   %d de %d objetos alterados\n", "   %d de %d objetos modificados\n"), len(changed), len(candidates))
+
+		if upgradeDryRun {
+			fmt.Println("\n" + yellow(common.T("AVISO:", "AVISO:")) + " " + common.T("Modo --dry-run: nenhuma alteração foi aplicada.", "Modo --dry-run: no se aplicó ningún cambio."))
+			return
+		}
+
+		fmt.Println("\n" + headerColor(common.T("Aplicando componentes alterados...", "Aplicando componentes modificados...")))
+		applyObjects(changed)
+
+		// O backend carrega os templates de laboratório na inicialização
+		if labsChanged && !slices.Contains(deployments, "girus-backend") {
+			restartCmd := k8s.KubectlCommand("rollout", "restart", "deployment/girus-backend", "-n", "girus")
+			if output, err := restartCmd.CombinedOutput(); err != nil {
+				fmt.Fprintf(os.Stderr, "%s %v\n%s\n", red(common.T("ERRO:", "ERROR:")), err, strings.TrimSpace(string(output)))
+				os.Exit(1)
+			}
+			deployments = append(deployments, "girus-backend")
+		}
+
+		for _, deployment := range deployments {
+			rolloutStep := fmt.Sprintf(common.T("Rolling update de %s", "Rolling update de %s"), deployment)
+			r.Start(rolloutStep)
+			if err := k8s.WaitForRollout("girus", deployment, 5*time.Minute, func(line string) {
+				r.Progress(rolloutStep, line, 0, 0)
+			}); err != nil {
+				r.Fail(rolloutStep, err)
+				os.Exit(1)
+			}
+			r.Done(rolloutStep, "")
+		}
+
+		fmt.Println("\n" + headerColor(common.T("Verificando a saúde do backend...", "Verificando la salud del backend...")))
+		if err := k8s.WaitForHealth(2 * time.Minute); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s %s\n", green(common.T("SUCESSO:", "ÉXITO:")), common.T("Girus atualizado e respondendo em /api/v1/health.", "Girus actualizado y respondiendo en /api/v1/health."))
+	},
+}
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, common.T("Apenas mostra os componentes que seriam atualizados", "Solo muestra los componentes que se actualizarían"))
+	upgradeCmd.Flags().BoolVarP(&upgradeVerbose, "verbose", "v", false, common.T("Exibe a diferença de cada objeto alterado", "Muestra la diferencia de cada objeto modificado"))
+	upgradeCmd.Flags().StringVar(&upgradeKubeconfig, "kubeconfig", "", common.T("caminho do kubeconfig de um cluster existente (padrão: $KUBECONFIG ou ~/.kube/config)", "ruta del kubeconfig de un cluster existente (predeterminado: $KUBECONFIG o ~/.kube/config)"))
+	upgradeCmd.Flags().StringVar(&upgradeContext, "context", "", common.T("contexto do kubeconfig de um cluster existente (padrão: o do cluster selecionado por --cluster)", "contexto del kubeconfig de un cluster existente (predeterminado: el del cluster seleccionado por --cluster)"))
+}
@@ -1,16 +1,58 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"github.com/badtuxx/girus-cli/internal/templates"
 	"github.com/spf13/cobra"
 )
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: common.T("Exibe a versão do Girus CLI", "Muestra la versión del Girus CLI"),
+	Long: common.T("Exibe a versão do Girus CLI e compara as versões esperadas dos componentes da plataforma com as implantadas no cluster selecionado.",
+		"Muestra la versión del Girus CLI y compara las versiones esperadas de los componentes de la plataforma con las desplegadas en el cluster seleccionado."),
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Print(common.GetVersion())
+
+		components, err := templates.Components()
+		if err != nil {
+			fmt.Printf("%s %v\n", red(common.T("ERRO:", "ERROR:")), err)
+			return
+		}
+
+		// O cluster pode não existir; nesse caso apenas as versões esperadas são exibidas
+		var client *k8s.KubernetesClient
+		if c, err := k8s.NewKubernetesClientForContext(common.KubeContext(), 3*time.Second); err == nil {
+			if _, err := c.ServerVersion(); err == nil {
+				client = c
+			}
+		}
+
+		fmt.Println("\ncomponentes:")
+		for _, component := range components {
+			deployed := common.T("cluster indisponível", "cluster no disponible")
+			if client != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				image, err := client.DeploymentImage(ctx, "girus", component.Name)
+				cancel()
+				switch {
+				case err != nil:
+					deployed = common.T("não implantado", "no desplegado")
+				case image == component.Image:
+					deployed = green(image)
+				default:
+					deployed = yellow(image + common.T(" (diferente do esperado)", " (diferente de lo esperado)"))
+				}
+			}
+
+			fmt.Printf("  %s\n", component.Name)
+			fmt.Printf("    esperado:   %s\n", component.Image)
+			fmt.Printf("    %s %s\n", common.T("implantado:", "desplegado:"), deployed)
+		}
 	},
 }
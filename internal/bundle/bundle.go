@@ -0,0 +1,142 @@
+package bundle
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+	"github.com/badtuxx/girus-cli/internal/templates"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultFile é o nome padrão do arquivo gerado por 'girus bundle create'
+const DefaultFile = "girus-bundle.tar"
+
+// ManifestImages retorna, ordenadas e sem repetição, as imagens referenciadas
+// pelos manifestos embutidos: os containers da plataforma e o campo image de
+// cada template de laboratório
+func ManifestImages() ([]string, error) {
+	manifestFiles, err := templates.ListManifests()
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar os manifestos embutidos: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for _, name := range manifestFiles {
+		content, err := templates.GetManifest(name)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao carregar o manifesto %s: %w", name, err)
+		}
+		objects, err := k8s.ParseManifest(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		for _, obj := range objects {
+			switch obj.GetKind() {
+			case "Deployment":
+				containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+				for _, container := range containers {
+					image, _, _ := unstructured.NestedString(container.(map[string]interface{}), "image")
+					if image != "" {
+						seen[image] = true
+					}
+				}
+			case "ConfigMap":
+				data, _ := obj.Object["data"].(map[string]interface{})
+				labYAML, ok := data[labschema.DataKey].(string)
+				if !ok {
+					continue
+				}
+				lab, err := labschema.ParseLab([]byte(labYAML))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				if lab.Image != "" {
+					seen[lab.Image] = true
+				}
+			}
+		}
+	}
+
+	images := make([]string, 0, len(seen))
+	for image := range seen {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// Create baixa as imagens ausentes no engine de containers local e as salva
+// em um único arquivo tar. onImage, quando informado, é chamado antes de cada imagem.
+func Create(path, containerEngine string, images []string, onImage func(image string)) error {
+	for _, image := range images {
+		if onImage != nil {
+			onImage(image)
+		}
+		if exec.Command(containerEngine, "image", "inspect", image).Run() == nil {
+			continue
+		}
+		if output, err := exec.Command(containerEngine, "pull", image).CombinedOutput(); err != nil {
+			return fmt.Errorf("falha ao baixar a imagem %s: %w\n%s", image, err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	args := append([]string{"save", "-o", path}, images...)
+	if output, err := exec.Command(containerEngine, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("falha ao salvar as imagens em %s: %w\n%s", path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Load importa as imagens do bundle no engine de containers local
+func Load(path, containerEngine string) error {
+	if output, err := exec.Command(containerEngine, "load", "-i", path).CombinedOutput(); err != nil {
+		return fmt.Errorf("falha ao importar o bundle %s: %w\n%s", path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Images lê o manifest.json do bundle e retorna as imagens que ele contém
+func Images(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir o bundle: %w", err)
+	}
+	defer f.Close()
+
+	reader := tar.NewReader(f)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s não é um bundle de imagens válido: manifest.json ausente", path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler o bundle: %w", err)
+		}
+		if header.Name != "manifest.json" {
+			continue
+		}
+
+		var manifest []struct {
+			RepoTags []string `json:"RepoTags"`
+		}
+		if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("falha ao interpretar o manifest.json do bundle: %w", err)
+		}
+
+		var images []string
+		for _, entry := range manifest {
+			images = append(images, entry.RepoTags...)
+		}
+		return images, nil
+	}
+}
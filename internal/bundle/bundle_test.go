@@ -0,0 +1,33 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/templates"
+)
+
+func TestManifestImages(t *testing.T) {
+	images, err := ManifestImages()
+	if err != nil {
+		t.Fatalf("ManifestImages retornou erro: %v", err)
+	}
+
+	want := map[string]bool{"linuxtips/girus-devops:0.1": false}
+	components, err := templates.Components()
+	if err != nil {
+		t.Fatalf("Components retornou erro: %v", err)
+	}
+	for _, component := range components {
+		want[component.Image] = false
+	}
+	for _, image := range images {
+		if _, ok := want[image]; ok {
+			want[image] = true
+		}
+	}
+	for image, found := range want {
+		if !found {
+			t.Errorf("imagem %s ausente em %v", image, images)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// Modos de acesso à aplicação a partir do host
+const (
+	// ExposePortForward usa 'girus port-forward' após a criação (padrão)
+	ExposePortForward = "port-forward"
+	// ExposeNodePort publica os serviços como NodePort mapeados para portas fixas do host
+	ExposeNodePort = "nodeport"
+	// ExposeIngress instala um ingress controller publicado nas portas 80 e 443 do host
+	ExposeIngress = "ingress"
+)
+
+// ExposeModes lista os modos de acesso suportados, com o padrão primeiro
+var ExposeModes = []string{ExposePortForward, ExposeNodePort, ExposeIngress}
+
+// NodePorts do frontend e do backend no modo nodeport
+const (
+	FrontendNodePort = 30080
+	BackendNodePort  = 30081
+)
+
+// IngressReadyLabel marca o nó onde o ingress-nginx do Kind é agendado
+const IngressReadyLabel = "ingress-ready"
+
+// ValidateExpose verifica se o modo de acesso é suportado
+func ValidateExpose(mode string) error {
+	if mode == "" || slices.Contains(ExposeModes, mode) {
+		return nil
+	}
+	return fmt.Errorf("modo de acesso não suportado: %s (use %s)", mode, strings.Join(ExposeModes, ", "))
+}
+
+// exposePortMappings retorna as portas do host publicadas no control-plane para o modo de acesso
+func exposePortMappings(t Topology) []v1alpha4.PortMapping {
+	switch t.Expose {
+	case ExposeNodePort:
+		ports := t.HostPorts()
+		return []v1alpha4.PortMapping{
+			{HostPort: int32(ports.Frontend), ContainerPort: FrontendNodePort},
+			{HostPort: int32(ports.Backend), ContainerPort: BackendNodePort},
+		}
+	case ExposeIngress:
+		return []v1alpha4.PortMapping{
+			{HostPort: 80, ContainerPort: 80},
+			{HostPort: 443, ContainerPort: 443},
+		}
+	}
+	return nil
+}
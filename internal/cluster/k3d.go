@@ -0,0 +1,182 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// K3sImageRepository é o repositório das imagens de nó usadas pelo k3d
+const K3sImageRepository = "rancher/k3s"
+
+// K3d gerencia clusters através do CLI do k3d (k3s em containers)
+type K3d struct {
+	onStep StepFunc
+}
+
+// NewK3d cria um gerenciador k3d, verificando se o binário está instalado
+func NewK3d(onStep StepFunc) (*K3d, error) {
+	if err := lookPath("k3d"); err != nil {
+		return nil, err
+	}
+	return &K3d{onStep: onStep}, nil
+}
+
+// Name retorna o nome do backend
+func (k *K3d) Name() string { return ProviderK3d }
+
+// List retorna os nomes de todos os clusters k3d existentes
+func (k *K3d) List() ([]string, error) {
+	output, err := runOutput("k3d", "cluster", "list", "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar clusters k3d: %w", err)
+	}
+
+	var clusters []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(output, &clusters); err != nil {
+		return nil, fmt.Errorf("falha ao interpretar a lista de clusters k3d: %w", err)
+	}
+
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	return names, nil
+}
+
+// Exists verifica se um cluster k3d com o nome informado existe
+func (k *K3d) Exists(name string) (bool, error) {
+	return contains(k, name)
+}
+
+// Create cria um cluster k3d com a topologia informada. Os workers viram
+// agents do k3s, as portas são publicadas no server e as montagens em todos os nós.
+func (k *K3d) Create(name string, topology Topology) error {
+	exists, err := k.Exists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrClusterExists, name)
+	}
+
+	args, err := k3dCreateArgs(name, topology)
+	if err != nil {
+		return err
+	}
+	if err := runSteps(k.onStep, "k3d", args...); err != nil {
+		return fmt.Errorf("falha ao criar o cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+// Delete exclui o cluster k3d e remove seu contexto do kubeconfig padrão
+func (k *K3d) Delete(name string) error {
+	exists, err := k.Exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	if _, err := runOutput("k3d", "cluster", "delete", name); err != nil {
+		return fmt.Errorf("falha ao excluir o cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+// Kubeconfig retorna o kubeconfig do cluster k3d
+func (k *K3d) Kubeconfig(name string) (string, error) {
+	output, err := runOutput("k3d", "kubeconfig", "get", name)
+	if err != nil {
+		return "", fmt.Errorf("falha ao obter o kubeconfig do cluster %s: %w", name, err)
+	}
+	return string(output), nil
+}
+
+// LoadImage importa imagens do engine de containers local no cluster k3d
+func (k *K3d) LoadImage(name string, images ...string) error {
+	args := append([]string{"image", "import", "-c", name}, images...)
+	if _, err := runOutput("k3d", args...); err != nil {
+		return fmt.Errorf("falha ao carregar as imagens no cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+// k3dCreateArgs traduz a topologia para os argumentos de 'k3d cluster create'
+func k3dCreateArgs(name string, topology Topology) ([]string, error) {
+	if err := topology.Validate(); err != nil {
+		return nil, err
+	}
+	if topology.Registry {
+		return nil, fmt.Errorf("o registry local do Girus só é suportado com o Kind")
+	}
+	if topology.Offline {
+		return nil, ValidateOffline(ProviderK3d)
+	}
+
+	args := []string{"cluster", "create", name, "--wait", "--timeout", "2m"}
+	if topology.Workers > 0 {
+		args = append(args, "--agents", fmt.Sprint(topology.Workers))
+	}
+
+	if topology.NodeImage != "" {
+		args = append(args, "--image", topology.NodeImage)
+	} else if topology.KubernetesVersion != "" {
+		version := topology.KubernetesVersion
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		args = append(args, "--image", fmt.Sprintf("%s:%s-k3s1", K3sImageRepository, version))
+	}
+
+	for _, spec := range topology.PortMappings {
+		mapping, _ := ParsePortMapping(spec)
+		port := fmt.Sprintf("%d:%d", mapping.HostPort, mapping.ContainerPort)
+		if mapping.ListenAddress != "" {
+			port = mapping.ListenAddress + ":" + port
+		}
+		if mapping.Protocol != "" {
+			port += "/" + strings.ToLower(string(mapping.Protocol))
+		}
+		args = append(args, "-p", port+"@server:0")
+	}
+
+	// O k3s já traz o Traefik como ingress controller, publicado pelo load balancer do k3d
+	switch topology.Expose {
+	case ExposeIngress:
+		args = append(args, "-p", "80:80@loadbalancer", "-p", "443:443@loadbalancer")
+	case ExposeNodePort:
+		for _, mapping := range exposePortMappings(topology) {
+			args = append(args, "-p", fmt.Sprintf("%d:%d@server:0", mapping.HostPort, mapping.ContainerPort))
+		}
+	}
+
+	for _, spec := range topology.Mounts {
+		mount, _ := ParseMount(spec)
+		volume := mount.HostPath + ":" + mount.ContainerPath
+		if mount.Readonly {
+			volume += ":ro"
+		}
+		args = append(args, "-v", volume+"@all")
+	}
+
+	return args, nil
+}
+
+// contains verifica se o nome aparece na lista de clusters do backend
+func contains(provider ClusterProvider, name string) (bool, error) {
+	clusters, err := provider.List()
+	if err != nil {
+		return false, err
+	}
+	for _, cluster := range clusters {
+		if cluster == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
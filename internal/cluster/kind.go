@@ -0,0 +1,248 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	kindcluster "sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	kindexec "sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+// ErrClusterExists indica que já existe um cluster Kind com o nome informado
+var ErrClusterExists = errors.New("cluster já existe")
+
+// ErrClusterNotFound indica que o cluster Kind informado não existe
+var ErrClusterNotFound = errors.New("cluster não encontrado")
+
+// StepEvent representa uma etapa reportada pelo Kind durante a criação do cluster
+type StepEvent struct {
+	Step   string
+	Done   bool
+	Failed bool
+}
+
+// StepFunc recebe os eventos de progresso emitidos pelo Kind
+type StepFunc func(StepEvent)
+
+// Kind gerencia clusters Kind através da biblioteca sigs.k8s.io/kind
+type Kind struct {
+	provider        *kindcluster.Provider
+	containerEngine string
+}
+
+// NewKind cria um gerenciador Kind para a engine de container informada
+// (docker ou podman). Uma engine vazia usa a detecção automática do Kind.
+func NewKind(containerEngine string, onStep StepFunc) (*Kind, error) {
+	options := []kindcluster.ProviderOption{
+		kindcluster.ProviderWithLogger(&stepLogger{onStep: onStep}),
+	}
+
+	switch containerEngine {
+	case "docker":
+		options = append(options, kindcluster.ProviderWithDocker())
+	case "podman":
+		options = append(options, kindcluster.ProviderWithPodman())
+	case "":
+		detected, err := kindcluster.DetectNodeProvider()
+		if err != nil {
+			return nil, fmt.Errorf("falha ao detectar a engine de container: %w", err)
+		}
+		if detected != nil {
+			options = append(options, detected)
+		}
+		// A mesma ordem de preferência do Kind: docker e, na falta dele, podman
+		containerEngine = "docker"
+		if _, err := exec.LookPath("docker"); err != nil {
+			containerEngine = "podman"
+		}
+	default:
+		return nil, fmt.Errorf("engine de container não suportada: %s", containerEngine)
+	}
+
+	return &Kind{provider: kindcluster.NewProvider(options...), containerEngine: containerEngine}, nil
+}
+
+// Name retorna o nome do backend
+func (k *Kind) Name() string { return ProviderKind }
+
+// List retorna os nomes de todos os clusters Kind existentes
+func (k *Kind) List() ([]string, error) {
+	clusters, err := k.provider.List()
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar clusters Kind: %w", err)
+	}
+	return clusters, nil
+}
+
+// Exists verifica se um cluster Kind com o nome informado existe
+func (k *Kind) Exists(name string) (bool, error) {
+	return contains(k, name)
+}
+
+// Create cria um cluster Kind com a topologia informada e aguarda o
+// control-plane ficar pronto
+func (k *Kind) Create(name string, topology Topology) error {
+	exists, err := k.Exists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrClusterExists, name)
+	}
+
+	options := []kindcluster.CreateOption{
+		kindcluster.CreateWithWaitForReady(2 * time.Minute),
+		kindcluster.CreateWithDisplayUsage(false),
+		kindcluster.CreateWithDisplaySalutation(false),
+	}
+	if !topology.IsDefault() {
+		config, err := topology.KindConfig()
+		if err != nil {
+			return err
+		}
+		options = append(options, kindcluster.CreateWithV1Alpha4Config(config))
+	}
+
+	err = k.provider.Create(name, options...)
+	if err != nil {
+		return fmt.Errorf("falha ao criar o cluster %s: %w", name, err)
+	}
+
+	if topology.Registry {
+		return k.configureRegistry(name)
+	}
+	return nil
+}
+
+// Delete exclui o cluster Kind e remove seu contexto do kubeconfig padrão
+func (k *Kind) Delete(name string) error {
+	exists, err := k.Exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	if err := k.provider.Delete(name, ""); err != nil {
+		return fmt.Errorf("falha ao excluir o cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+// Kubeconfig retorna o kubeconfig externo do cluster Kind
+func (k *Kind) Kubeconfig(name string) (string, error) {
+	kubeconfig, err := k.provider.KubeConfig(name, false)
+	if err != nil {
+		return "", fmt.Errorf("falha ao obter o kubeconfig do cluster %s: %w", name, err)
+	}
+	return kubeconfig, nil
+}
+
+// LoadImage exporta as imagens do engine de containers local e as importa
+// no containerd de cada nó do cluster Kind
+func (k *Kind) LoadImage(name string, images ...string) error {
+	nodes, err := k.provider.ListInternalNodes(name)
+	if err != nil {
+		return fmt.Errorf("falha ao listar os nós do cluster %s: %w", name, err)
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	archive, err := os.CreateTemp("", "girus-images-*.tar")
+	if err != nil {
+		return fmt.Errorf("falha ao criar arquivo temporário: %w", err)
+	}
+	archive.Close()
+	defer os.Remove(archive.Name())
+
+	args := append([]string{"save", "-o", archive.Name()}, images...)
+	if _, err := runOutput(k.containerEngine, args...); err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		f, err := os.Open(archive.Name())
+		if err != nil {
+			return err
+		}
+		err = nodeutils.LoadImageArchive(node, f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("falha ao carregar as imagens no nó %s: %w", node.String(), err)
+		}
+	}
+	return nil
+}
+
+// CommandOutput retorna a saída do comando da engine de container que
+// causou o erro, quando disponível
+func CommandOutput(err error) string {
+	if output := outputOf(err); output != "" {
+		return output
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		if runErr := kindexec.RunErrorForError(err); runErr != nil {
+			return strings.TrimSpace(string(runErr.Output))
+		}
+	}
+	return ""
+}
+
+// stepLogger implementa log.Logger do Kind, convertendo as mensagens de
+// status ("•", "✓" e "✗") em eventos de progresso
+type stepLogger struct {
+	onStep StepFunc
+}
+
+func (l *stepLogger) Warn(message string) {}
+
+func (l *stepLogger) Warnf(format string, args ...interface{}) {}
+
+func (l *stepLogger) Error(message string) {}
+
+func (l *stepLogger) Errorf(format string, args ...interface{}) {}
+
+func (l *stepLogger) V(level log.Level) log.InfoLogger {
+	if level > 0 || l.onStep == nil {
+		return log.NoopInfoLogger{}
+	}
+	return l
+}
+
+func (l *stepLogger) Info(message string) {
+	if event, ok := parseStatus(message); ok {
+		l.onStep(event)
+	}
+}
+
+func (l *stepLogger) Infof(format string, args ...interface{}) {
+	l.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *stepLogger) Enabled() bool {
+	return true
+}
+
+// parseStatus interpreta uma linha de status do Kind
+func parseStatus(message string) (StepEvent, bool) {
+	line := strings.TrimSpace(message)
+	switch {
+	case strings.HasPrefix(line, "•"):
+		step := strings.TrimSpace(strings.TrimPrefix(line, "•"))
+		step = strings.TrimSpace(strings.TrimSuffix(step, "..."))
+		return StepEvent{Step: step}, true
+	case strings.HasPrefix(line, "✓"):
+		return StepEvent{Step: strings.TrimSpace(strings.TrimPrefix(line, "✓")), Done: true}, true
+	case strings.HasPrefix(line, "✗"):
+		return StepEvent{Step: strings.TrimSpace(strings.TrimPrefix(line, "✗")), Done: true, Failed: true}, true
+	}
+	return StepEvent{}, false
+}
@@ -0,0 +1,23 @@
+package cluster
+
+import "testing"
+
+func TestParseStatus(t *testing.T) {
+	cases := []struct {
+		message string
+		want    StepEvent
+		ok      bool
+	}{
+		{" • Ensuring node image (kindest/node:v1.33.1) 🖼  ...\n", StepEvent{Step: "Ensuring node image (kindest/node:v1.33.1) 🖼"}, true},
+		{" ✓ Preparing nodes 📦 \n", StepEvent{Step: "Preparing nodes 📦", Done: true}, true},
+		{" ✗ Starting control-plane 🕹️\n", StepEvent{Step: "Starting control-plane 🕹️", Done: true, Failed: true}, true},
+		{"Creating cluster \"girus\" ...\n", StepEvent{}, false},
+	}
+
+	for _, c := range cases {
+		got, ok := parseStatus(c.message)
+		if ok != c.ok || got != c.want {
+			t.Errorf("parseStatus(%q) = %+v, %v; esperado %+v, %v", c.message, got, ok, c.want, c.ok)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Minikube gerencia clusters através do CLI do minikube, um perfil por cluster
+type Minikube struct {
+	containerEngine string
+	onStep          StepFunc
+}
+
+// NewMinikube cria um gerenciador minikube que usa a engine de container
+// informada como driver. Uma engine vazia mantém o driver padrão do minikube.
+func NewMinikube(containerEngine string, onStep StepFunc) (*Minikube, error) {
+	if err := lookPath("minikube"); err != nil {
+		return nil, err
+	}
+	return &Minikube{containerEngine: containerEngine, onStep: onStep}, nil
+}
+
+// Name retorna o nome do backend
+func (m *Minikube) Name() string { return ProviderMinikube }
+
+// List retorna os nomes de todos os perfis válidos do minikube
+func (m *Minikube) List() ([]string, error) {
+	// O minikube retorna erro quando não há nenhum perfil, mas ainda
+	// imprime um JSON válido na saída padrão
+	output, err := exec.Command("minikube", "profile", "list", "-o", "json").Output()
+	var profiles struct {
+		Valid []struct {
+			Name string `json:"Name"`
+		} `json:"valid"`
+	}
+	if jsonErr := json.Unmarshal(output, &profiles); jsonErr != nil {
+		if err != nil {
+			return nil, fmt.Errorf("falha ao listar perfis do minikube: %w", err)
+		}
+		return nil, fmt.Errorf("falha ao interpretar a lista de perfis do minikube: %w", jsonErr)
+	}
+
+	names := make([]string, 0, len(profiles.Valid))
+	for _, profile := range profiles.Valid {
+		names = append(names, profile.Name)
+	}
+	return names, nil
+}
+
+// Exists verifica se um perfil do minikube com o nome informado existe
+func (m *Minikube) Exists(name string) (bool, error) {
+	return contains(m, name)
+}
+
+// Create inicia um perfil do minikube com a topologia informada
+func (m *Minikube) Create(name string, topology Topology) error {
+	exists, err := m.Exists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrClusterExists, name)
+	}
+
+	args, err := minikubeStartArgs(name, m.containerEngine, topology)
+	if err != nil {
+		return err
+	}
+	if err := runSteps(m.onStep, "minikube", args...); err != nil {
+		return fmt.Errorf("falha ao criar o cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+// Delete exclui o perfil do minikube e remove seu contexto do kubeconfig padrão
+func (m *Minikube) Delete(name string) error {
+	exists, err := m.Exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	if _, err := runOutput("minikube", "delete", "-p", name); err != nil {
+		return fmt.Errorf("falha ao excluir o cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+// Kubeconfig retorna o kubeconfig do perfil, extraído do kubeconfig padrão
+// onde o minikube registra o contexto
+func (m *Minikube) Kubeconfig(name string) (string, error) {
+	output, err := runOutput("kubectl", "config", "view", "--raw", "--minify", "--flatten", "--context", name)
+	if err != nil {
+		return "", fmt.Errorf("falha ao obter o kubeconfig do cluster %s: %w", name, err)
+	}
+	return string(output), nil
+}
+
+// LoadImage carrega imagens do engine de containers local no perfil do minikube
+func (m *Minikube) LoadImage(name string, images ...string) error {
+	for _, image := range images {
+		if _, err := runOutput("minikube", "image", "load", "-p", name, image); err != nil {
+			return fmt.Errorf("falha ao carregar a imagem %s no cluster %s: %w", image, name, err)
+		}
+	}
+	return nil
+}
+
+// minikubeStartArgs traduz a topologia para os argumentos de 'minikube start'
+func minikubeStartArgs(name, containerEngine string, topology Topology) ([]string, error) {
+	if err := topology.Validate(); err != nil {
+		return nil, err
+	}
+	if topology.Registry {
+		return nil, fmt.Errorf("o registry local do Girus só é suportado com o Kind")
+	}
+	if topology.Offline {
+		return nil, ValidateOffline(ProviderMinikube)
+	}
+	if topology.NodeImage != "" {
+		return nil, fmt.Errorf("o minikube não aceita imagem de nó; use a versão do Kubernetes")
+	}
+	if topology.Expose == ExposeIngress {
+		return nil, fmt.Errorf("o modo de acesso ingress não é suportado com o minikube; use nodeport")
+	}
+	if len(topology.Mounts) > 1 {
+		return nil, fmt.Errorf("o minikube aceita apenas uma montagem por cluster")
+	}
+
+	args := []string{"start", "-p", name, "--wait", "all"}
+	if containerEngine != "" {
+		args = append(args, "--driver", containerEngine)
+	}
+	if topology.Workers > 0 {
+		args = append(args, "--nodes", fmt.Sprint(topology.Workers+1))
+	}
+	if topology.KubernetesVersion != "" {
+		version := topology.KubernetesVersion
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		args = append(args, "--kubernetes-version", version)
+	}
+	for _, mapping := range exposePortMappings(topology) {
+		args = append(args, "--ports", fmt.Sprintf("%d:%d", mapping.HostPort, mapping.ContainerPort))
+	}
+	for _, spec := range topology.PortMappings {
+		args = append(args, "--ports", spec)
+	}
+	for _, spec := range topology.Mounts {
+		mount, _ := ParseMount(spec)
+		args = append(args, "--mount", "--mount-string", mount.HostPath+":"+mount.ContainerPath)
+	}
+
+	return args, nil
+}
@@ -0,0 +1,175 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ProviderKind, ProviderK3d e ProviderMinikube são os backends de cluster suportados
+const (
+	ProviderKind     = "kind"
+	ProviderK3d      = "k3d"
+	ProviderMinikube = "minikube"
+)
+
+// Providers lista os backends de cluster suportados, com o padrão primeiro
+var Providers = []string{ProviderKind, ProviderK3d, ProviderMinikube}
+
+// ClusterProvider abstrai a ferramenta que cria os clusters locais do Girus
+type ClusterProvider interface {
+	// Name retorna o nome do backend (kind, k3d ou minikube)
+	Name() string
+	// Create cria o cluster com a topologia informada e aguarda ficar pronto
+	Create(name string, topology Topology) error
+	// Delete exclui o cluster e remove seu contexto do kubeconfig
+	Delete(name string) error
+	// List retorna os nomes dos clusters existentes neste backend
+	List() ([]string, error)
+	// Exists verifica se o cluster existe
+	Exists(name string) (bool, error)
+	// Kubeconfig retorna o conteúdo do kubeconfig de acesso ao cluster
+	Kubeconfig(name string) (string, error)
+	// LoadImage carrega imagens do engine de containers local nos nós do cluster
+	LoadImage(name string, images ...string) error
+}
+
+var (
+	_ ClusterProvider = (*Kind)(nil)
+	_ ClusterProvider = (*K3d)(nil)
+	_ ClusterProvider = (*Minikube)(nil)
+)
+
+// NewProvider cria o backend de cluster informado. Um nome vazio seleciona o Kind.
+func NewProvider(name, containerEngine string, onStep StepFunc) (ClusterProvider, error) {
+	switch name {
+	case "", ProviderKind:
+		return NewKind(containerEngine, onStep)
+	case ProviderK3d:
+		return NewK3d(onStep)
+	case ProviderMinikube:
+		return NewMinikube(containerEngine, onStep)
+	}
+	return nil, fmt.Errorf("provedor de cluster não suportado: %s (use %s)", name, strings.Join(Providers, ", "))
+}
+
+// ValidateOffline verifica se o backend cria clusters a partir de um bundle de
+// imagens, sem internet. Só o Kind é suportado: o k3d baixa suas imagens
+// auxiliares (k3d-tools e k3d-proxy) e o minikube a imagem base e o preload do
+// Kubernetes, que não fazem parte do bundle.
+func ValidateOffline(provider string) error {
+	if provider != "" && provider != ProviderKind {
+		return fmt.Errorf("o bundle de imagens só é suportado com o Kind: o %s baixa da internet imagens que não fazem parte do bundle", provider)
+	}
+	return nil
+}
+
+// Render retorna a configuração efetiva usada para criar o cluster, sem criá-lo:
+// o arquivo de configuração do Kind ou a linha de comando do k3d/minikube.
+// O segundo valor é o nome sugerido para o arquivo.
+func Render(provider, name, containerEngine string, topology Topology) ([]byte, string, error) {
+	switch provider {
+	case "", ProviderKind:
+		config, err := topology.KindConfig()
+		if err != nil {
+			return nil, "", err
+		}
+		content, err := yaml.Marshal(config)
+		if err != nil {
+			return nil, "", fmt.Errorf("falha ao serializar a configuração do Kind: %w", err)
+		}
+		return content, "kind-config.yaml", nil
+	case ProviderK3d:
+		args, err := k3dCreateArgs(name, topology)
+		if err != nil {
+			return nil, "", err
+		}
+		return []byte("k3d " + strings.Join(args, " ") + "\n"), "k3d-command.txt", nil
+	case ProviderMinikube:
+		args, err := minikubeStartArgs(name, containerEngine, topology)
+		if err != nil {
+			return nil, "", err
+		}
+		return []byte("minikube " + strings.Join(args, " ") + "\n"), "minikube-command.txt", nil
+	}
+	return nil, "", fmt.Errorf("provedor de cluster não suportado: %s (use %s)", provider, strings.Join(Providers, ", "))
+}
+
+// commandError guarda a saída de um comando externo que falhou
+type commandError struct {
+	err    error
+	output string
+}
+
+func (e *commandError) Error() string { return e.err.Error() }
+
+func (e *commandError) Unwrap() error { return e.err }
+
+// lookPath confirma que o binário de um backend está instalado
+func lookPath(binary string) error {
+	if _, err := exec.LookPath(binary); err != nil {
+		return fmt.Errorf("%s não encontrado no PATH: instale-o ou escolha outro provedor com --provider", binary)
+	}
+	return nil
+}
+
+// runOutput executa um comando e retorna sua saída padrão
+func runOutput(name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &commandError{err: fmt.Errorf("falha ao executar %s %s: %w", name, args[0], err), output: stderr.String()}
+	}
+	return output, nil
+}
+
+// runSteps executa um comando repassando cada linha de saída como uma etapa de progresso
+func runSteps(onStep StepFunc, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("falha ao executar %s: %w", name, err)
+	}
+
+	var output strings.Builder
+	scanner := bufio.NewScanner(pipe)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		output.WriteString(line + "\n")
+		if onStep != nil {
+			onStep(StepEvent{Step: line})
+		}
+	}
+	// Consumir o restante da saída caso a leitura tenha sido interrompida
+	_, _ = io.Copy(io.Discard, pipe)
+
+	if err := cmd.Wait(); err != nil {
+		return &commandError{err: fmt.Errorf("falha ao executar %s %s: %w", name, args[0], err), output: output.String()}
+	}
+	return nil
+}
+
+// outputOf extrai a saída de um commandError na cadeia de erros
+func outputOf(err error) string {
+	var cmdErr *commandError
+	if errors.As(err, &cmdErr) {
+		return strings.TrimSpace(cmdErr.output)
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package cluster
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestK3dCreateArgs(t *testing.T) {
+	args, err := k3dCreateArgs("girus", Topology{
+		Workers:           2,
+		KubernetesVersion: "1.33.1",
+		PortMappings:      []string{"127.0.0.1:8081:30081/tcp"},
+	})
+	if err != nil {
+		t.Fatalf("k3dCreateArgs retornou erro: %v", err)
+	}
+
+	want := []string{"cluster", "create", "girus", "--wait", "--timeout", "2m",
+		"--agents", "2",
+		"--image", "rancher/k3s:v1.33.1-k3s1",
+		"-p", "127.0.0.1:8081:30081/tcp@server:0"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("obtido %v, esperado %v", args, want)
+	}
+}
+
+func TestMinikubeStartArgs(t *testing.T) {
+	args, err := minikubeStartArgs("girus", "docker", Topology{Workers: 1, KubernetesVersion: "v1.33.1"})
+	if err != nil {
+		t.Fatalf("minikubeStartArgs retornou erro: %v", err)
+	}
+
+	want := []string{"start", "-p", "girus", "--wait", "all", "--driver", "docker", "--nodes", "2", "--kubernetes-version", "v1.33.1"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("obtido %v, esperado %v", args, want)
+	}
+
+	if _, err := minikubeStartArgs("girus", "", Topology{NodeImage: "kindest/node:v1.33.1"}); err == nil {
+		t.Errorf("imagem de nó deveria ser rejeitada pelo minikube")
+	}
+}
+
+func TestValidateOffline(t *testing.T) {
+	if err := ValidateOffline(ProviderKind); err != nil {
+		t.Errorf("o Kind deveria aceitar o bundle: %v", err)
+	}
+	if _, err := k3dCreateArgs("girus", Topology{Offline: true}); err == nil {
+		t.Errorf("o k3d deveria rejeitar o bundle")
+	}
+	if _, err := minikubeStartArgs("girus", "docker", Topology{Offline: true}); err == nil {
+		t.Errorf("o minikube deveria rejeitar o bundle")
+	}
+}
+
+func TestNewProviderUnsupported(t *testing.T) {
+	if _, err := NewProvider("docker-desktop", "", nil); err == nil {
+		t.Errorf("NewProvider deveria rejeitar um provedor desconhecido")
+	}
+}
+
+func TestRender(t *testing.T) {
+	content, name, err := Render(ProviderKind, "girus", "", Topology{Workers: 1})
+	if err != nil {
+		t.Fatalf("Render retornou erro: %v", err)
+	}
+	if name != "kind-config.yaml" || !strings.Contains(string(content), "role: worker") {
+		t.Errorf("configuração do Kind inesperada (%s):\n%s", name, content)
+	}
+
+	content, name, err = Render(ProviderK3d, "girus", "", Topology{})
+	if err != nil {
+		t.Fatalf("Render retornou erro: %v", err)
+	}
+	if name != "k3d-command.txt" || !strings.HasPrefix(string(content), "k3d cluster create girus") {
+		t.Errorf("comando do k3d inesperado (%s): %s", name, content)
+	}
+}
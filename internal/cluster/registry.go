@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+)
+
+const (
+	// RegistryName é o registry local que recebe as imagens de 'girus registry push'
+	RegistryName = "girus-registry"
+	// RegistryMirrorName é o registry que funciona como cache (pull-through) do Docker Hub
+	RegistryMirrorName = "girus-registry-mirror"
+	// RegistryHost é o endereço do registry local no host e dentro dos nós
+	RegistryHost = "localhost:5001"
+	// RegistryImage é a imagem usada pelos dois registries
+	RegistryImage = "registry:2"
+
+	// kindNetwork é a rede do engine de containers onde os nós do Kind executam
+	kindNetwork = "kind"
+	// containerdCertsDir é onde o containerd dos nós procura a configuração de cada registry
+	containerdCertsDir = "/etc/containerd/certs.d"
+)
+
+// registryConfigPatch faz o containerd dos nós ler a configuração de registries de containerdCertsDir
+const registryConfigPatch = `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "` + containerdCertsDir + `"`
+
+// Registry gerencia os containers de registry compartilhados pelos clusters.
+// Os containers e seus volumes não são removidos junto com o cluster, então
+// as imagens baixadas uma vez continuam disponíveis nas próximas recriações.
+type Registry struct {
+	containerEngine string
+}
+
+// NewRegistry cria um gerenciador de registry para a engine de container informada
+func NewRegistry(containerEngine string) *Registry {
+	if containerEngine == "" {
+		containerEngine = "docker"
+	}
+	return &Registry{containerEngine: containerEngine}
+}
+
+// Ensure inicia os registries, criando os containers na primeira execução
+func (r *Registry) Ensure() error {
+	if err := r.ensureContainer(RegistryName, "-p", "127.0.0.1:5001:5000"); err != nil {
+		return err
+	}
+	return r.ensureContainer(RegistryMirrorName, "-e", "REGISTRY_PROXY_REMOTEURL=https://registry-1.docker.io")
+}
+
+// ensureContainer inicia um container de registry, persistindo os dados em um volume de mesmo nome
+func (r *Registry) ensureContainer(name string, args ...string) error {
+	output, err := exec.Command(r.containerEngine, "inspect", "-f", "{{.State.Running}}", name).Output()
+	if err == nil {
+		if strings.TrimSpace(string(output)) == "true" {
+			return nil
+		}
+		if _, err := runOutput(r.containerEngine, "start", name); err != nil {
+			return fmt.Errorf("falha ao iniciar o registry %s: %w", name, err)
+		}
+		return nil
+	}
+
+	runArgs := append([]string{"run", "-d", "--restart=always", "--name", name, "-v", name + ":/var/lib/registry"}, args...)
+	runArgs = append(runArgs, RegistryImage)
+	if _, err := runOutput(r.containerEngine, runArgs...); err != nil {
+		return fmt.Errorf("falha ao criar o registry %s: %w", name, err)
+	}
+	return nil
+}
+
+// Push envia uma imagem local para o registry local e retorna a referência
+// que deve ser usada no campo image dos laboratórios
+func (r *Registry) Push(image string) (string, error) {
+	target := RegistryHost + "/" + imagePath(image)
+
+	if _, err := runOutput(r.containerEngine, "tag", image, target); err != nil {
+		return "", fmt.Errorf("falha ao marcar a imagem %s: %w", image, err)
+	}
+
+	args := []string{"push", target}
+	if r.containerEngine == "podman" {
+		args = append(args, "--tls-verify=false")
+	}
+	if _, err := runOutput(r.containerEngine, args...); err != nil {
+		return "", fmt.Errorf("falha ao enviar a imagem %s: %w", target, err)
+	}
+	return target, nil
+}
+
+// imagePath remove o endereço do registry de uma referência de imagem
+func imagePath(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[1]
+	}
+	return image
+}
+
+// configureRegistry conecta os registries à rede do Kind e configura o
+// containerd de cada nó para usá-los: o registry local em RegistryHost e o
+// cache como espelho do Docker Hub
+func (k *Kind) configureRegistry(name string) error {
+	for _, container := range []string{RegistryName, RegistryMirrorName} {
+		output, err := runOutput(k.containerEngine, "inspect", "-f", "{{json .NetworkSettings.Networks."+kindNetwork+"}}", container)
+		if err != nil {
+			return fmt.Errorf("registry %s não encontrado: %w", container, err)
+		}
+		if strings.TrimSpace(string(output)) != "null" {
+			continue
+		}
+		if _, err := runOutput(k.containerEngine, "network", "connect", kindNetwork, container); err != nil {
+			return fmt.Errorf("falha ao conectar o registry %s à rede do Kind: %w", container, err)
+		}
+	}
+
+	hosts := map[string]string{
+		RegistryHost: fmt.Sprintf("[host.\"http://%s:5000\"]\n", RegistryName),
+		"docker.io":  fmt.Sprintf("server = \"https://registry-1.docker.io\"\n\n[host.\"http://%s:5000\"]\n  capabilities = [\"pull\", \"resolve\"]\n", RegistryMirrorName),
+	}
+
+	nodes, err := k.provider.ListInternalNodes(name)
+	if err != nil {
+		return fmt.Errorf("falha ao listar os nós do cluster %s: %w", name, err)
+	}
+	for _, node := range nodes {
+		for host, config := range hosts {
+			if err := nodeutils.WriteFile(node, containerdCertsDir+"/"+host+"/hosts.toml", config); err != nil {
+				return fmt.Errorf("falha ao configurar o registry no nó %s: %w", node.String(), err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package cluster
+
+import "testing"
+
+func TestImagePath(t *testing.T) {
+	cases := map[string]string{
+		"minha-imagem:1.0":                 "minha-imagem:1.0",
+		"linuxtips/girus-devops:0.1":       "linuxtips/girus-devops:0.1",
+		"ghcr.io/badtuxx/lab:latest":       "badtuxx/lab:latest",
+		"localhost/lab:dev":                "lab:dev",
+		"registry.local:5000/equipe/lab:2": "equipe/lab:2",
+	}
+	for image, want := range cases {
+		if got := imagePath(image); got != want {
+			t.Errorf("imagePath(%q) = %q, esperado %q", image, got, want)
+		}
+	}
+}
+
+func TestTopologyRegistryPatch(t *testing.T) {
+	topology := Topology{Registry: true}
+	if topology.IsDefault() {
+		t.Fatalf("topologia com registry não deveria ser a padrão")
+	}
+
+	config, err := topology.KindConfig()
+	if err != nil {
+		t.Fatalf("KindConfig retornou erro: %v", err)
+	}
+	if len(config.ContainerdConfigPatches) != 1 {
+		t.Errorf("esperado um patch do containerd, obtidos %d", len(config.ContainerdConfigPatches))
+	}
+
+	if _, err := k3dCreateArgs("girus", topology); err == nil {
+		t.Errorf("o k3d deveria rejeitar o registry do Girus")
+	}
+}
@@ -0,0 +1,248 @@
+package cluster
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// KindNodeImageRepository é o repositório das imagens de nó publicadas pelo Kind
+const KindNodeImageRepository = "kindest/node"
+
+// Topology descreve o formato do cluster Kind que será criado
+type Topology struct {
+	Workers           int
+	NodeImage         string
+	KubernetesVersion string
+	PortMappings      []string
+	Mounts            []string
+	// Registry conecta o containerd dos nós aos registries locais do Girus
+	Registry bool
+	// Expose é o modo de acesso à aplicação (veja ExposeModes)
+	Expose string
+	// Ports são as portas do host do frontend e do backend publicadas no modo
+	// nodeport; zero usa as portas padrão
+	Ports common.HostPorts
+	// Offline indica que o cluster é criado a partir de um bundle de imagens,
+	// sem acesso à internet
+	Offline bool
+}
+
+// HostPorts retorna as portas do host do frontend e do backend, com as
+// portas padrão no lugar das não informadas
+func (t Topology) HostPorts() common.HostPorts {
+	ports := t.Ports
+	if ports.Frontend == 0 {
+		ports.Frontend = common.DefaultFrontendPort
+	}
+	if ports.Backend == 0 {
+		ports.Backend = common.DefaultBackendPort
+	}
+	return ports
+}
+
+// Image retorna a imagem de nó efetiva, derivando-a da versão do Kubernetes
+// quando nenhuma imagem explícita foi informada
+func (t Topology) Image() string {
+	if t.NodeImage != "" {
+		return t.NodeImage
+	}
+	if t.KubernetesVersion != "" {
+		version := t.KubernetesVersion
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		return fmt.Sprintf("%s:%s", KindNodeImageRepository, version)
+	}
+	return ""
+}
+
+// IsDefault indica se a topologia equivale ao cluster padrão do Kind
+// (um único nó, imagem padrão, sem portas, montagens extras, registry ou
+// modo de acesso que publique portas)
+func (t Topology) IsDefault() bool {
+	return t.Workers == 0 && t.Image() == "" && len(t.PortMappings) == 0 && len(t.Mounts) == 0 && !t.Registry && len(exposePortMappings(t)) == 0
+}
+
+// Validate verifica se as opções da topologia são consistentes
+func (t Topology) Validate() error {
+	if t.Workers < 0 {
+		return fmt.Errorf("o número de workers não pode ser negativo: %d", t.Workers)
+	}
+	if t.NodeImage != "" && t.KubernetesVersion != "" {
+		return fmt.Errorf("informe apenas a imagem do nó ou a versão do Kubernetes, não ambas")
+	}
+	if err := ValidateExpose(t.Expose); err != nil {
+		return err
+	}
+	if t.Offline && t.Expose == ExposeIngress {
+		// O ingress controller é instalado a partir de um manifesto baixado da
+		// internet, e suas imagens não fazem parte do bundle
+		return fmt.Errorf("o modo de acesso %s precisa de internet e não pode ser usado com um bundle: use %s ou %s", ExposeIngress, ExposePortForward, ExposeNodePort)
+	}
+	ports := t.HostPorts()
+	for _, port := range []int{ports.Frontend, ports.Backend} {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("porta do host inválida: %d", port)
+		}
+	}
+	if ports.Frontend == ports.Backend {
+		return fmt.Errorf("o frontend e o backend não podem usar a mesma porta: %d", ports.Frontend)
+	}
+	for _, spec := range t.PortMappings {
+		if _, err := ParsePortMapping(spec); err != nil {
+			return err
+		}
+	}
+	for _, spec := range t.Mounts {
+		if _, err := ParseMount(spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KindConfig gera a configuração v1alpha4 do Kind para a topologia.
+// As portas extras são expostas no control-plane e as montagens são
+// aplicadas a todos os nós, para que os pods dos laboratórios as encontrem
+// independentemente do nó em que forem agendados.
+func (t Topology) KindConfig() (*v1alpha4.Cluster, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	portMappings := exposePortMappings(t)
+	for _, spec := range t.PortMappings {
+		mapping, _ := ParsePortMapping(spec)
+		portMappings = append(portMappings, mapping)
+	}
+
+	var mounts []v1alpha4.Mount
+	for _, spec := range t.Mounts {
+		mount, _ := ParseMount(spec)
+		mounts = append(mounts, mount)
+	}
+
+	image := t.Image()
+	config := &v1alpha4.Cluster{
+		TypeMeta: v1alpha4.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: "kind.x-k8s.io/v1alpha4",
+		},
+		Nodes: []v1alpha4.Node{
+			{
+				Role:              v1alpha4.ControlPlaneRole,
+				Image:             image,
+				ExtraPortMappings: portMappings,
+				ExtraMounts:       mounts,
+			},
+		},
+	}
+
+	if t.Expose == ExposeIngress {
+		config.Nodes[0].Labels = map[string]string{IngressReadyLabel: "true"}
+	}
+
+	if t.Registry {
+		config.ContainerdConfigPatches = []string{registryConfigPatch}
+	}
+
+	for i := 0; i < t.Workers; i++ {
+		config.Nodes = append(config.Nodes, v1alpha4.Node{
+			Role:        v1alpha4.WorkerRole,
+			Image:       image,
+			ExtraMounts: mounts,
+		})
+	}
+
+	return config, nil
+}
+
+// ParsePortMapping interpreta um mapeamento no formato
+// [endereço:]portaHost:portaContainer[/protocolo], por exemplo "8081:30081/tcp"
+func ParsePortMapping(spec string) (v1alpha4.PortMapping, error) {
+	var mapping v1alpha4.PortMapping
+
+	ports := spec
+	if idx := strings.LastIndex(spec, "/"); idx >= 0 {
+		ports = spec[:idx]
+		switch strings.ToUpper(spec[idx+1:]) {
+		case "TCP":
+			mapping.Protocol = v1alpha4.PortMappingProtocolTCP
+		case "UDP":
+			mapping.Protocol = v1alpha4.PortMappingProtocolUDP
+		case "SCTP":
+			mapping.Protocol = v1alpha4.PortMappingProtocolSCTP
+		default:
+			return mapping, fmt.Errorf("protocolo inválido no mapeamento de porta %q", spec)
+		}
+	}
+
+	parts := strings.Split(ports, ":")
+	switch len(parts) {
+	case 2:
+	case 3:
+		mapping.ListenAddress = parts[0]
+		parts = parts[1:]
+	default:
+		return mapping, fmt.Errorf("mapeamento de porta inválido %q (use portaHost:portaContainer)", spec)
+	}
+
+	hostPort, err := parsePort(parts[0])
+	if err != nil {
+		return mapping, fmt.Errorf("porta do host inválida em %q: %w", spec, err)
+	}
+	containerPort, err := parsePort(parts[1])
+	if err != nil {
+		return mapping, fmt.Errorf("porta do container inválida em %q: %w", spec, err)
+	}
+
+	mapping.HostPort = hostPort
+	mapping.ContainerPort = containerPort
+	return mapping, nil
+}
+
+// ParseMount interpreta uma montagem no formato caminhoHost:caminhoContainer[:ro]
+func ParseMount(spec string) (v1alpha4.Mount, error) {
+	var mount v1alpha4.Mount
+
+	parts := strings.Split(spec, ":")
+	if len(parts) == 3 {
+		if parts[2] != "ro" && parts[2] != "rw" {
+			return mount, fmt.Errorf("opção de montagem inválida em %q (use ro ou rw)", spec)
+		}
+		mount.Readonly = parts[2] == "ro"
+		parts = parts[:2]
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return mount, fmt.Errorf("montagem inválida %q (use caminhoHost:caminhoContainer[:ro])", spec)
+	}
+	if !strings.HasPrefix(parts[1], "/") {
+		return mount, fmt.Errorf("o caminho no container deve ser absoluto em %q", spec)
+	}
+
+	hostPath, err := filepath.Abs(parts[0])
+	if err != nil {
+		return mount, fmt.Errorf("caminho do host inválido em %q: %w", spec, err)
+	}
+
+	mount.HostPath = hostPath
+	mount.ContainerPath = parts[1]
+	return mount, nil
+}
+
+// parsePort converte e valida um número de porta
+func parsePort(value string) (int32, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("porta fora do intervalo: %d", port)
+	}
+	return int32(port), nil
+}
@@ -0,0 +1,115 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func TestTopologyKindConfig(t *testing.T) {
+	topology := Topology{
+		Workers:           2,
+		KubernetesVersion: "1.32.2",
+		PortMappings:      []string{"8081:30081"},
+		Mounts:            []string{"/tmp/girus:/data:ro"},
+	}
+
+	config, err := topology.KindConfig()
+	if err != nil {
+		t.Fatalf("KindConfig retornou erro: %v", err)
+	}
+
+	if len(config.Nodes) != 3 {
+		t.Fatalf("esperados 3 nós, obtidos %d", len(config.Nodes))
+	}
+	if config.Nodes[0].Role != v1alpha4.ControlPlaneRole || config.Nodes[1].Role != v1alpha4.WorkerRole {
+		t.Errorf("papéis inesperados: %s, %s", config.Nodes[0].Role, config.Nodes[1].Role)
+	}
+	for _, node := range config.Nodes {
+		if node.Image != "kindest/node:v1.32.2" {
+			t.Errorf("imagem inesperada: %s", node.Image)
+		}
+		if len(node.ExtraMounts) != 1 || !node.ExtraMounts[0].Readonly {
+			t.Errorf("montagem ausente ou não somente leitura no nó %s", node.Role)
+		}
+	}
+	if len(config.Nodes[0].ExtraPortMappings) != 1 || len(config.Nodes[1].ExtraPortMappings) != 0 {
+		t.Errorf("portas extras devem existir apenas no control-plane")
+	}
+}
+
+func TestParsePortMapping(t *testing.T) {
+	mapping, err := ParsePortMapping("127.0.0.1:8443:443/tcp")
+	if err != nil {
+		t.Fatalf("ParsePortMapping retornou erro: %v", err)
+	}
+	want := v1alpha4.PortMapping{ListenAddress: "127.0.0.1", HostPort: 8443, ContainerPort: 443, Protocol: v1alpha4.PortMappingProtocolTCP}
+	if mapping != want {
+		t.Errorf("obtido %+v, esperado %+v", mapping, want)
+	}
+
+	for _, spec := range []string{"8080", "8080:99999", "a:b", "8080:80/icmp"} {
+		if _, err := ParsePortMapping(spec); err == nil {
+			t.Errorf("ParsePortMapping(%q) deveria falhar", spec)
+		}
+	}
+}
+
+func TestTopologyValidate(t *testing.T) {
+	invalid := []Topology{
+		{Workers: -1},
+		{NodeImage: "kindest/node:v1.33.1", KubernetesVersion: "v1.33.1"},
+		{Mounts: []string{"/data:relativo"}},
+		{Expose: ExposeIngress, Offline: true},
+	}
+	for _, topology := range invalid {
+		if err := topology.Validate(); err == nil {
+			t.Errorf("Validate(%+v) deveria falhar", topology)
+		}
+	}
+
+	if err := (Topology{Expose: ExposeNodePort, Offline: true}).Validate(); err != nil {
+		t.Errorf("nodeport com bundle deveria ser válido: %v", err)
+	}
+
+	if !(Topology{}).IsDefault() {
+		t.Errorf("topologia vazia deveria ser a padrão")
+	}
+}
+
+func TestTopologyKindConfigIngress(t *testing.T) {
+	config, err := Topology{Expose: ExposeIngress}.KindConfig()
+	if err != nil {
+		t.Fatalf("KindConfig retornou erro: %v", err)
+	}
+
+	controlPlane := config.Nodes[0]
+	if controlPlane.Labels[IngressReadyLabel] != "true" {
+		t.Errorf("rótulo %s ausente no control-plane", IngressReadyLabel)
+	}
+	if len(controlPlane.ExtraPortMappings) != 2 || controlPlane.ExtraPortMappings[0].HostPort != 80 {
+		t.Errorf("portas 80/443 não mapeadas: %+v", controlPlane.ExtraPortMappings)
+	}
+	if (Topology{Expose: ExposeNodePort}).IsDefault() {
+		t.Errorf("modo nodeport não deve usar a configuração padrão")
+	}
+}
+
+func TestTopologyNodePortPorts(t *testing.T) {
+	topology := Topology{Expose: ExposeNodePort, Ports: common.HostPorts{Frontend: 9000}}
+
+	config, err := topology.KindConfig()
+	if err != nil {
+		t.Fatalf("KindConfig retornou erro: %v", err)
+	}
+	mappings := config.Nodes[0].ExtraPortMappings
+	if len(mappings) != 2 || mappings[0].HostPort != 9000 || mappings[1].HostPort != common.DefaultBackendPort {
+		t.Errorf("portas do host inesperadas: %+v", mappings)
+	}
+
+	topology.Ports.Backend = 9000
+	if err := topology.Validate(); err == nil {
+		t.Errorf("portas repetidas deveriam ser rejeitadas")
+	}
+}
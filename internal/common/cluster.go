@@ -0,0 +1,155 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultClusterName é o nome do cluster usado quando nenhum outro é configurado
+const DefaultClusterName = "girus"
+
+// DefaultClusterProvider é o backend usado para criar os clusters locais
+const DefaultClusterProvider = "kind"
+
+var (
+	clusterName     = DefaultClusterName
+	clusterProvider = DefaultClusterProvider
+
+	// externalCluster indica que os comandos atuam sobre um cluster existente
+	// (modo 'girus install'), e não sobre um cluster Kind criado pelo Girus
+	externalCluster bool
+	kubeconfigPath  string
+	kubeContext     string
+)
+
+// SetClusterName define o cluster Girus sobre o qual os comandos atuam
+func SetClusterName(name string) {
+	if name != "" {
+		clusterName = name
+	}
+}
+
+// ClusterName retorna o nome do cluster Girus selecionado
+func ClusterName() string { return clusterName }
+
+// SetClusterProvider define o backend (kind, k3d ou minikube) dos clusters locais
+func SetClusterProvider(provider string) {
+	if provider != "" {
+		clusterProvider = provider
+	}
+}
+
+// ClusterProvider retorna o backend dos clusters locais selecionado
+func ClusterProvider() string { return clusterProvider }
+
+// clustersFile guarda, em ~/.girus, o backend com que cada cluster foi criado
+const clustersFile = "clusters.yaml"
+
+// UseSavedClusterProvider seleciona o backend gravado por SaveClusterProvider
+// para o cluster selecionado, mantendo o configurado quando não há registro.
+// Assim 'girus --cluster lab2 status' encontra um cluster criado com
+// '--provider k3d' sem que --provider seja repetido.
+func UseSavedClusterProvider() {
+	if provider, ok := loadClusterProviders()[clusterName]; ok {
+		SetClusterProvider(provider)
+	}
+}
+
+// SaveClusterProvider grava o backend selecionado como o do cluster selecionado
+func SaveClusterProvider() error {
+	saved := loadClusterProviders()
+	saved[clusterName] = clusterProvider
+	return writeClusterProviders(saved)
+}
+
+// ForgetClusterProvider remove o registro do backend do cluster selecionado
+func ForgetClusterProvider() error {
+	saved := loadClusterProviders()
+	if _, ok := saved[clusterName]; !ok {
+		return nil
+	}
+	delete(saved, clusterName)
+	return writeClusterProviders(saved)
+}
+
+// writeClusterProviders grava os backends indexados pelo nome do cluster
+func writeClusterProviders(saved map[string]string) error {
+	path, err := clustersPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("falha ao serializar os provedores dos clusters: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("falha ao criar o diretório %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("falha ao gravar %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadClusterProviders lê os backends gravados, indexados pelo nome do cluster
+func loadClusterProviders() map[string]string {
+	saved := map[string]string{}
+	path, err := clustersPath()
+	if err != nil {
+		return saved
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return saved
+	}
+	if err := yaml.Unmarshal(data, &saved); err != nil || saved == nil {
+		return map[string]string{}
+	}
+	return saved
+}
+
+// clustersPath retorna o caminho de ~/.girus/clusters.yaml
+func clustersPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".girus", clustersFile), nil
+}
+
+// SetExternalCluster seleciona um cluster Kubernetes existente pelo kubeconfig
+// e contexto informados. Valores vazios usam o kubeconfig padrão e o current-context.
+func SetExternalCluster(kubeconfig, context string) {
+	externalCluster = true
+	kubeconfigPath = kubeconfig
+	kubeContext = context
+}
+
+// IsExternalCluster indica se um cluster existente foi selecionado
+func IsExternalCluster() bool { return externalCluster }
+
+// Kubeconfig retorna o caminho explícito do kubeconfig ("" usa o padrão)
+func Kubeconfig() string { return kubeconfigPath }
+
+// KubeContext retorna o contexto do kubeconfig do cluster selecionado
+func KubeContext() string {
+	if externalCluster {
+		return kubeContext
+	}
+	return KubeContextFor(clusterName)
+}
+
+// KubeContextFor retorna o contexto que o backend selecionado cria no
+// kubeconfig para o cluster informado
+func KubeContextFor(name string) string {
+	switch clusterProvider {
+	case "k3d":
+		return "k3d-" + name
+	case "minikube":
+		return name
+	}
+	return "kind-" + name
+}
@@ -0,0 +1,41 @@
+package common
+
+import "testing"
+
+func TestSavedClusterProvider(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func() {
+		SetClusterName(DefaultClusterName)
+		SetClusterProvider(DefaultClusterProvider)
+	}()
+
+	SetClusterName("lab2")
+	SetClusterProvider("k3d")
+	if err := SaveClusterProvider(); err != nil {
+		t.Fatalf("SaveClusterProvider retornou erro: %v", err)
+	}
+
+	// Sem --provider, o cluster volta a usar o backend com que foi criado
+	SetClusterProvider(DefaultClusterProvider)
+	UseSavedClusterProvider()
+	if got := KubeContext(); got != "k3d-lab2" {
+		t.Errorf("KubeContext() = %q, esperado k3d-lab2", got)
+	}
+
+	// Clusters sem registro mantêm o backend configurado
+	SetClusterName("outro")
+	SetClusterProvider(DefaultClusterProvider)
+	UseSavedClusterProvider()
+	if got := KubeContext(); got != "kind-outro" {
+		t.Errorf("KubeContext() = %q, esperado kind-outro", got)
+	}
+
+	SetClusterName("lab2")
+	if err := ForgetClusterProvider(); err != nil {
+		t.Fatalf("ForgetClusterProvider retornou erro: %v", err)
+	}
+	UseSavedClusterProvider()
+	if got := KubeContext(); got != "kind-lab2" {
+		t.Errorf("KubeContext() após ForgetClusterProvider = %q, esperado kind-lab2", got)
+	}
+}
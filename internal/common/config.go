@@ -8,7 +8,31 @@ import (
 )
 
 type Config struct {
-	Language string `yaml:"language"`
+	Language string        `yaml:"language"`
+	Cluster  ClusterConfig `yaml:"cluster"`
+	Lint     LintConfig    `yaml:"lint"`
+}
+
+// LintConfig ajusta as regras de 'girus lab lint'
+type LintConfig struct {
+	// Rules associa o ID de cada regra ao nível: error, warning, info ou off
+	Rules map[string]string `yaml:"rules"`
+}
+
+// ClusterConfig define o cluster selecionado por padrão e a topologia usada
+// por 'girus create cluster'
+type ClusterConfig struct {
+	Name              string   `yaml:"name"`
+	Provider          string   `yaml:"provider"`
+	Workers           int      `yaml:"workers"`
+	NodeImage         string   `yaml:"nodeImage"`
+	KubernetesVersion string   `yaml:"kubernetesVersion"`
+	PortMappings      []string `yaml:"portMappings"`
+	Mounts            []string `yaml:"mounts"`
+	Registry          bool     `yaml:"registry"`
+	Expose            string   `yaml:"expose"`
+	FrontendPort      int      `yaml:"frontendPort"`
+	BackendPort       int      `yaml:"backendPort"`
 }
 
 var configPath string
@@ -3,4 +3,6 @@ package common
 func init() {
 	cfg := LoadConfig()
 	SetLanguage(cfg.Language)
+	SetClusterName(cfg.Cluster.Name)
+	SetClusterProvider(cfg.Cluster.Provider)
 }
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"os"
+	"strconv"
+)
+
+// NonInteractiveEnv desativa as perguntas quando definida com um valor verdadeiro
+// (ex: GIRUS_NONINTERACTIVE=1 em pipelines de CI ou no cloud-init)
+const NonInteractiveEnv = "GIRUS_NONINTERACTIVE"
+
+var (
+	nonInteractive bool
+	assumeYes      bool
+)
+
+// SetNonInteractive ativa o modo não interativo (flag global --non-interactive)
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// SetAssumeYes faz todas as confirmações serem aceitas (flag global --yes)
+func SetAssumeYes(enabled bool) {
+	assumeYes = enabled
+}
+
+// AssumeYes indica se as confirmações devem ser aceitas sem perguntar
+func AssumeYes() bool { return assumeYes }
+
+// NonInteractive indica se nada deve ser perguntado, pelas flags globais
+// --non-interactive ou --yes ou pela variável GIRUS_NONINTERACTIVE
+func NonInteractive() bool {
+	if nonInteractive || assumeYes {
+		return true
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv(NonInteractiveEnv))
+	return enabled
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Portas padrão do host para o frontend e o backend do Girus
+const (
+	DefaultFrontendPort = 8000
+	DefaultBackendPort  = 8080
+)
+
+// portsFile guarda, em ~/.girus, as portas escolhidas para cada cluster
+const portsFile = "ports.yaml"
+
+// HostPorts são as portas do host usadas para acessar o frontend e o backend
+type HostPorts struct {
+	Frontend int `yaml:"frontend"`
+	Backend  int `yaml:"backend"`
+}
+
+// ConfiguredPorts retorna as portas definidas em cluster.frontendPort e
+// cluster.backendPort no arquivo de configuração, ou as padrão
+func ConfiguredPorts() HostPorts {
+	cfg := LoadConfig().Cluster
+	ports := HostPorts{Frontend: cfg.FrontendPort, Backend: cfg.BackendPort}
+	if ports.Frontend == 0 {
+		ports.Frontend = DefaultFrontendPort
+	}
+	if ports.Backend == 0 {
+		ports.Backend = DefaultBackendPort
+	}
+	return ports
+}
+
+// Ports retorna as portas do cluster selecionado: as gravadas por SavePorts
+// na criação do cluster ou do port-forward, ou as configuradas
+func Ports() HostPorts {
+	if ports, ok := loadPorts()[KubeContext()]; ok && ports.Frontend != 0 && ports.Backend != 0 {
+		return ports
+	}
+	return ConfiguredPorts()
+}
+
+// SavePorts grava as portas escolhidas para o cluster selecionado, usadas por
+// todos os comandos seguintes
+func SavePorts(ports HostPorts) error {
+	path, err := portsPath()
+	if err != nil {
+		return err
+	}
+	saved := loadPorts()
+	saved[KubeContext()] = ports
+
+	data, err := yaml.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("falha ao serializar as portas: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("falha ao criar o diretório %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("falha ao gravar %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadPorts lê as portas gravadas, indexadas pelo contexto do cluster
+func loadPorts() map[string]HostPorts {
+	saved := map[string]HostPorts{}
+	path, err := portsPath()
+	if err != nil {
+		return saved
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return saved
+	}
+	if err := yaml.Unmarshal(data, &saved); err != nil || saved == nil {
+		return map[string]HostPorts{}
+	}
+	return saved
+}
+
+// portsPath retorna o caminho de ~/.girus/ports.yaml
+func portsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".girus", portsFile), nil
+}
@@ -22,6 +22,11 @@ func getDefaultIfEmpty(value, defaultValue string) string {
 	return value
 }
 
+// ShortVersion retorna apenas a versão do CLI, ou "dev" nas builds locais
+func ShortVersion() string {
+	return getDefaultIfEmpty(Version, "dev")
+}
+
 func GetVersion() string {
 	version := getDefaultIfEmpty(Version, "dev")
 	buildUser := getDefaultIfEmpty(BuildUser, "unknown")
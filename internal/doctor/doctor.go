@@ -0,0 +1,358 @@
+package doctor
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Status é o resultado de uma verificação
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+const (
+	gib = 1 << 30
+
+	// Recursos mínimos e recomendados para o cluster e os laboratórios
+	minMemory         = 2 * gib
+	recommendedMemory = 4 * gib
+	recommendedCPUs   = 2
+
+	// Limites de inotify recomendados pela documentação do Kind
+	recommendedMaxUserWatches   = 524288
+	recommendedMaxUserInstances = 512
+)
+
+// Check é o resultado de uma verificação do ambiente
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// Options seleciona o que é verificado
+type Options struct {
+	// ContainerEngine é a engine usada pelos nós do cluster (docker ou podman)
+	ContainerEngine string
+	// Provider é o backend de cluster (kind, k3d ou minikube)
+	Provider string
+	// KubeContext é o contexto verificado; vazio pula a verificação (ex: antes de criar o cluster)
+	KubeContext string
+	// KubectlOptional rebaixa a falta do kubectl a aviso; a criação do cluster
+	// usa apenas client-go, mas comandos como status e lab ainda usam o kubectl
+	KubectlOptional bool
+}
+
+// Run executa todas as verificações na ordem em que são exibidas
+func Run(opts Options) []Check {
+	if opts.ContainerEngine == "" {
+		opts.ContainerEngine = "docker"
+	}
+	if opts.Provider == "" {
+		opts.Provider = cluster.ProviderKind
+	}
+
+	kubectlMissing := StatusFail
+	if opts.KubectlOptional {
+		kubectlMissing = StatusWarn
+	}
+	checks := []Check{
+		checkProviderTool(opts.Provider),
+		checkTool("kubectl", kubectlMissing, "kubectl", "version", "--client"),
+	}
+
+	engine, info := checkEngine(opts.ContainerEngine)
+	checks = append(checks, engine)
+	if info != nil {
+		checks = append(checks, checkResources(*info), checkCgroup(*info))
+	}
+
+	ports := common.Ports()
+	for _, port := range []int{ports.Frontend, ports.Backend} {
+		checks = append(checks, checkPort(port))
+	}
+	if runtime.GOOS == "linux" {
+		checks = append(checks, checkInotify())
+	}
+	if opts.KubeContext != "" {
+		checks = append(checks, checkContext(opts.KubeContext))
+	}
+	return checks
+}
+
+// Failed verifica se alguma verificação falhou
+func Failed(checks []Check) bool {
+	for _, check := range checks {
+		if check.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// checkProviderTool verifica o binário do provedor de cluster. O Kind é usado
+// como biblioteca, então a falta do binário 'kind' é apenas um aviso.
+func checkProviderTool(provider string) Check {
+	switch provider {
+	case cluster.ProviderK3d:
+		return checkTool("k3d", StatusFail, "k3d", "version")
+	case cluster.ProviderMinikube:
+		return checkTool("minikube", StatusFail, "minikube", "version", "--short")
+	}
+	return checkTool("kind", StatusWarn, "kind", "version")
+}
+
+// checkTool verifica se um binário está disponível e retorna a primeira linha de sua versão
+func checkTool(name string, missing Status, command string, args ...string) Check {
+	check := Check{Name: name}
+	if _, err := exec.LookPath(command); err != nil {
+		check.Status = missing
+		check.Message = common.T("não encontrado no PATH", "no encontrado en el PATH")
+		check.Hint = toolHint(name)
+		return check
+	}
+
+	output, err := exec.Command(command, args...).CombinedOutput()
+	if err != nil {
+		check.Status = StatusWarn
+		check.Message = common.T("não foi possível obter a versão", "no fue posible obtener la versión")
+		return check
+	}
+	check.Status = StatusPass
+	check.Message = strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	return check
+}
+
+// toolHint retorna onde obter cada ferramenta
+func toolHint(name string) string {
+	switch name {
+	case "kind":
+		return common.T("Opcional: o Girus cria clusters Kind sem o binário. Instalação: https://kind.sigs.k8s.io/docs/user/quick-start/#installation", "Opcional: Girus crea clusters Kind sin el binario. Instalación: https://kind.sigs.k8s.io/docs/user/quick-start/#installation")
+	case "k3d":
+		return "https://k3d.io/#installation"
+	case "minikube":
+		return "https://minikube.sigs.k8s.io/docs/start/"
+	}
+	return "https://kubernetes.io/docs/tasks/tools/"
+}
+
+// engineInfo reúne os dados do 'info' da engine de containers
+type engineInfo struct {
+	CPUs          int
+	Memory        int64
+	CgroupVersion string
+}
+
+// engineInfoFormats são os templates do 'info' de cada engine (CPUs|memória|cgroup)
+var engineInfoFormats = map[string]string{
+	"docker": "{{.NCPU}}|{{.MemTotal}}|{{.CgroupVersion}}",
+	"podman": "{{.Host.CPUs}}|{{.Host.MemTotal}}|{{.Host.CgroupsVersion}}",
+}
+
+// checkEngine verifica se a engine está instalada e em execução. Os recursos
+// são lidos da engine, pois no macOS e no Windows ela executa em uma VM.
+func checkEngine(engine string) (Check, *engineInfo) {
+	check := Check{Name: engine}
+
+	version, err := exec.Command(engine, "--version").Output()
+	if err != nil {
+		check.Status = StatusFail
+		check.Message = common.T("não encontrado", "no encontrado")
+		check.Hint = engineInstallHint(engine, runtime.GOOS)
+		return check, nil
+	}
+
+	format, ok := engineInfoFormats[engine]
+	if !ok {
+		format = engineInfoFormats["docker"]
+	}
+	output, err := exec.Command(engine, "info", "--format", format).Output()
+	if err != nil {
+		check.Status = StatusFail
+		check.Message = common.T("instalado, mas o serviço não está em execução", "instalado, pero el servicio no está en ejecución")
+		check.Hint = engineStartHint(engine, runtime.GOOS)
+		return check, nil
+	}
+
+	check.Status = StatusPass
+	check.Message = strings.TrimSpace(string(version))
+	info, err := parseEngineInfo(string(output))
+	if err != nil {
+		return check, nil
+	}
+	return check, info
+}
+
+// parseEngineInfo interpreta a saída de engineInfoFormats
+func parseEngineInfo(output string) (*engineInfo, error) {
+	parts := strings.Split(strings.TrimSpace(output), "|")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("saída inesperada: %q", output)
+	}
+	cpus, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("número de CPUs inválido: %w", err)
+	}
+	memory, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("memória inválida: %w", err)
+	}
+	return &engineInfo{
+		CPUs:          cpus,
+		Memory:        memory,
+		CgroupVersion: strings.TrimPrefix(parts[2], "v"),
+	}, nil
+}
+
+// checkResources compara a memória e as CPUs disponíveis para a engine com o recomendado
+func checkResources(info engineInfo) Check {
+	check := Check{
+		Name:    common.T("recursos", "recursos"),
+		Status:  StatusPass,
+		Message: fmt.Sprintf("%d CPUs, %.1f GiB", info.CPUs, float64(info.Memory)/gib),
+	}
+	switch {
+	case info.Memory < minMemory:
+		check.Status = StatusFail
+		check.Hint = common.T("O cluster precisa de pelo menos 2 GiB de memória para a engine de containers", "El cluster necesita al menos 2 GiB de memoria para la engine de contenedores")
+	case info.Memory < recommendedMemory || info.CPUs < recommendedCPUs:
+		check.Status = StatusWarn
+		check.Hint = common.T("Recomendado: 2 CPUs e 4 GiB de memória para a engine de containers", "Recomendado: 2 CPUs y 4 GiB de memoria para la engine de contenedores")
+	}
+	return check
+}
+
+// checkCgroup verifica a versão do cgroup usada pela engine
+func checkCgroup(info engineInfo) Check {
+	check := Check{Name: "cgroup", Status: StatusPass, Message: "v" + info.CgroupVersion}
+	switch info.CgroupVersion {
+	case "2":
+	case "1":
+		check.Status = StatusWarn
+		check.Hint = common.T("cgroup v1 está obsoleto nas versões recentes do Kubernetes; prefira um sistema com cgroup v2", "cgroup v1 está obsoleto en las versiones recientes de Kubernetes; prefiera un sistema con cgroup v2")
+	default:
+		check.Status = StatusWarn
+		check.Message = common.T("versão desconhecida", "versión desconocida")
+	}
+	return check
+}
+
+// checkPort verifica se uma porta do host está livre para o acesso ao Girus
+func checkPort(port int) Check {
+	check := Check{Name: fmt.Sprintf(common.T("porta %d", "puerto %d"), port), Status: StatusPass, Message: common.T("livre", "libre")}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		check.Status = StatusWarn
+		check.Message = common.T("em uso", "en uso")
+		check.Hint = common.T("Pode ser um port-forward do Girus já em execução; caso contrário, o Girus usará a próxima porta livre", "Puede ser un port-forward de Girus ya en ejecución; de lo contrario, Girus usará el siguiente puerto libre")
+		return check
+	}
+	listener.Close()
+	return check
+}
+
+// checkInotify verifica os limites de inotify, que esgotam com vários nós do Kind
+func checkInotify() Check {
+	check := Check{Name: "inotify", Status: StatusPass}
+	watches, errWatches := readProcInt("/proc/sys/fs/inotify/max_user_watches")
+	instances, errInstances := readProcInt("/proc/sys/fs/inotify/max_user_instances")
+	if errWatches != nil || errInstances != nil {
+		check.Status = StatusWarn
+		check.Message = common.T("não foi possível ler os limites", "no fue posible leer los límites")
+		return check
+	}
+
+	check.Message = fmt.Sprintf("max_user_watches=%d, max_user_instances=%d", watches, instances)
+	if watches < recommendedMaxUserWatches || instances < recommendedMaxUserInstances {
+		check.Status = StatusWarn
+		check.Hint = fmt.Sprintf("sudo sysctl fs.inotify.max_user_watches=%d fs.inotify.max_user_instances=%d", recommendedMaxUserWatches, recommendedMaxUserInstances)
+	}
+	return check
+}
+
+// readProcInt lê um valor inteiro de /proc
+func readProcInt(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
+
+// checkContext verifica se o contexto existe no kubeconfig e se a API responde
+func checkContext(kubeContext string) Check {
+	check := Check{Name: fmt.Sprintf(common.T("contexto %s", "contexto %s"), kubeContext)}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = common.Kubeconfig()
+	rawConfig, err := loadingRules.Load()
+	if err != nil || rawConfig.Contexts[kubeContext] == nil {
+		check.Status = StatusWarn
+		check.Message = common.T("não encontrado no kubeconfig", "no encontrado en el kubeconfig")
+		check.Hint = common.T("Crie o cluster com 'girus create cluster'", "Cree el cluster con 'girus create cluster'")
+		return check
+	}
+
+	client, err := k8s.NewKubernetesClientForContext(kubeContext, 5*time.Second)
+	if err == nil {
+		var version string
+		if version, err = client.ServerVersion(); err == nil {
+			check.Status = StatusPass
+			check.Message = fmt.Sprintf(common.T("acessível (Kubernetes %s)", "accesible (Kubernetes %s)"), version)
+			return check
+		}
+	}
+	check.Status = StatusFail
+	check.Message = common.T("API do cluster inacessível", "API del cluster inaccesible")
+	check.Hint = common.T("Verifique se o cluster está em execução ('girus start')", "Verifique si el cluster está en ejecución ('girus start')")
+	return check
+}
+
+// engineInstallHint retorna as instruções de instalação da engine para o sistema operacional
+func engineInstallHint(engine, goos string) string {
+	switch {
+	case goos == "darwin" && engine == "docker":
+		return common.T("macOS: brew install colima docker && colima start (ou instale o Docker Desktop: https://www.docker.com/products/docker-desktop)",
+			"macOS: brew install colima docker && colima start (o instale Docker Desktop: https://www.docker.com/products/docker-desktop)")
+	case goos == "linux" && engine == "docker":
+		return common.T("Linux: curl -fsSL https://get.docker.com | bash && sudo usermod -aG docker $USER && sudo systemctl enable --now docker",
+			"Linux: curl -fsSL https://get.docker.com | bash && sudo usermod -aG docker $USER && sudo systemctl enable --now docker")
+	case goos == "darwin" && engine == "podman":
+		return "macOS: brew install podman && podman machine init && podman machine start"
+	case goos == "linux" && engine == "podman":
+		return common.T("Linux: instale o pacote podman da sua distribuição; para uso rootless veja https://github.com/containers/podman/blob/main/docs/tutorials/rootless_tutorial.md",
+			"Linux: instale el paquete podman de su distribución; para uso rootless vea https://github.com/containers/podman/blob/main/docs/tutorials/rootless_tutorial.md")
+	case engine == "podman":
+		return "https://github.com/containers/podman/blob/main/docs/tutorials/podman-for-windows.md"
+	}
+	return "https://www.docker.com/products/docker-desktop"
+}
+
+// engineStartHint retorna como iniciar o serviço da engine no sistema operacional
+func engineStartHint(engine, goos string) string {
+	switch {
+	case goos == "darwin" && engine == "docker":
+		return common.T("colima start (ou inicie o Docker Desktop)", "colima start (o inicie Docker Desktop)")
+	case goos == "darwin" && engine == "podman":
+		return "podman machine start"
+	case goos == "linux":
+		return "sudo systemctl start " + engine
+	}
+	return common.T("Inicie o serviço de containers apropriado para seu sistema", "Inicie el servicio de contenedores apropiado para su sistema")
+}
@@ -0,0 +1,52 @@
+package doctor
+
+import "testing"
+
+func TestParseEngineInfo(t *testing.T) {
+	tests := []struct {
+		output string
+		cgroup string
+	}{
+		{"4|8254017536|2\n", "2"},
+		{"2|4103405568|v2", "2"},
+	}
+	for _, tt := range tests {
+		info, err := parseEngineInfo(tt.output)
+		if err != nil {
+			t.Fatalf("parseEngineInfo(%q) retornou erro: %v", tt.output, err)
+		}
+		if info.CgroupVersion != tt.cgroup {
+			t.Errorf("parseEngineInfo(%q) cgroup = %q, esperado %q", tt.output, info.CgroupVersion, tt.cgroup)
+		}
+	}
+
+	if _, err := parseEngineInfo("sem formato"); err == nil {
+		t.Error("parseEngineInfo deveria falhar com saída inesperada")
+	}
+}
+
+func TestCheckResources(t *testing.T) {
+	tests := []struct {
+		info engineInfo
+		want Status
+	}{
+		{engineInfo{CPUs: 4, Memory: 8 * gib}, StatusPass},
+		{engineInfo{CPUs: 1, Memory: 8 * gib}, StatusWarn},
+		{engineInfo{CPUs: 4, Memory: 3 * gib}, StatusWarn},
+		{engineInfo{CPUs: 4, Memory: 1 * gib}, StatusFail},
+	}
+	for _, tt := range tests {
+		if got := checkResources(tt.info).Status; got != tt.want {
+			t.Errorf("checkResources(%+v) = %s, esperado %s", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestFailed(t *testing.T) {
+	if Failed([]Check{{Status: StatusPass}, {Status: StatusWarn}}) {
+		t.Error("avisos não deveriam ser considerados falhas")
+	}
+	if !Failed([]Check{{Status: StatusPass}, {Status: StatusFail}}) {
+		t.Error("uma verificação com falha deveria ser detectada")
+	}
+}
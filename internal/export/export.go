@@ -0,0 +1,321 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Formatos de exportação suportados
+const (
+	FormatHelm      = "helm"
+	FormatKustomize = "kustomize"
+)
+
+// Formats lista os formatos de exportação suportados
+var Formats = []string{FormatHelm, FormatKustomize}
+
+const (
+	// defaultNamespace é o namespace da plataforma nos manifestos embutidos
+	defaultNamespace = "girus"
+	// labTemplateLabel identifica os ConfigMaps de template de laboratório
+	labTemplateLabel = "girus-lab-template"
+)
+
+// Resources são os recursos dos pods de laboratório (bloco lab.resources do girus-config)
+type Resources struct {
+	CPURequest    string `yaml:"cpuRequest"`
+	CPULimit      string `yaml:"cpuLimit"`
+	MemoryRequest string `yaml:"memoryRequest"`
+	MemoryLimit   string `yaml:"memoryLimit"`
+}
+
+// Images são as imagens da plataforma
+type Images struct {
+	Backend  string `yaml:"backend"`
+	Frontend string `yaml:"frontend"`
+}
+
+// Values são os parâmetros da exportação. No Helm viram o values.yaml; no
+// Kustomize são aplicados diretamente aos arquivos gerados.
+type Values struct {
+	Namespace string `yaml:"namespace"`
+	Images    Images `yaml:"images"`
+	Lab       struct {
+		Resources Resources `yaml:"resources"`
+	} `yaml:"lab"`
+	// Labs são os nomes dos templates de laboratório incluídos
+	Labs []string `yaml:"labs"`
+}
+
+// resourceKeys associa cada campo de lab.resources à sua chave no config.yaml
+var resourceKeys = []string{"cpuRequest", "cpuLimit", "memoryRequest", "memoryLimit"}
+
+// platform reúne os objetos embutidos separados em plataforma e laboratórios
+type platform struct {
+	objects []*unstructured.Unstructured
+	labs    map[string][]*unstructured.Unstructured
+}
+
+// DefaultValues retorna os valores dos manifestos embutidos no idioma selecionado
+func DefaultValues() (Values, error) {
+	p, err := load()
+	if err != nil {
+		return Values{}, err
+	}
+
+	values := Values{
+		Namespace: defaultNamespace,
+		Images: Images{
+			Backend:  p.image("girus-backend"),
+			Frontend: p.image("girus-frontend"),
+		},
+	}
+	for name := range p.labs {
+		values.Labs = append(values.Labs, name)
+	}
+	slices.Sort(values.Labs)
+
+	config, err := p.girusConfig()
+	if err != nil {
+		return Values{}, err
+	}
+	var parsed struct {
+		Lab struct {
+			Resources Resources `yaml:"resources"`
+		} `yaml:"lab"`
+	}
+	if err := yaml.Unmarshal([]byte(config), &parsed); err != nil {
+		return Values{}, fmt.Errorf("falha ao interpretar o girus-config: %w", err)
+	}
+	values.Lab.Resources = parsed.Lab.Resources
+	return values, nil
+}
+
+// Write gera o chart Helm ou a base Kustomize em outDir e retorna os arquivos criados
+func Write(format, outDir string, values Values) ([]string, error) {
+	p, err := load()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range values.Labs {
+		if _, ok := p.labs[name]; !ok {
+			return nil, fmt.Errorf("template de laboratório não encontrado: %s", name)
+		}
+	}
+
+	var files map[string][]byte
+	switch format {
+	case FormatHelm:
+		files, err = p.helm(values)
+	case FormatKustomize:
+		files, err = p.kustomize(values)
+	default:
+		return nil, fmt.Errorf("formato não suportado: %s (use %s)", format, strings.Join(Formats, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for name, content := range files {
+		path := filepath.Join(outDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("falha ao criar o diretório %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return nil, fmt.Errorf("falha ao escrever %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	slices.Sort(written)
+	return written, nil
+}
+
+// load separa os objetos embutidos em plataforma e templates de laboratório
+func load() (*platform, error) {
+	objects, err := k8s.EmbeddedObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &platform{labs: map[string][]*unstructured.Unstructured{}}
+	for _, obj := range objects {
+		if obj.GetLabels()["app"] == labTemplateLabel {
+			p.labs[obj.GetName()] = append(p.labs[obj.GetName()], obj)
+			continue
+		}
+		p.objects = append(p.objects, obj)
+	}
+	return p, nil
+}
+
+// girusConfig retorna o config.yaml do ConfigMap girus-config
+func (p *platform) girusConfig() (string, error) {
+	for _, obj := range p.objects {
+		if obj.GetKind() == "ConfigMap" && obj.GetName() == "girus-config" {
+			config, _, _ := unstructured.NestedString(obj.Object, "data", "config.yaml")
+			return config, nil
+		}
+	}
+	return "", fmt.Errorf("ConfigMap girus-config não encontrado nos manifestos embutidos")
+}
+
+// image retorna a imagem do primeiro container de um deployment da plataforma
+func (p *platform) image(deployment string) string {
+	for _, obj := range p.objects {
+		if obj.GetKind() != "Deployment" || obj.GetName() != deployment {
+			continue
+		}
+		containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+		if len(containers) > 0 {
+			if container, ok := containers[0].(map[string]interface{}); ok {
+				image, _ := container["image"].(string)
+				return image
+			}
+		}
+	}
+	return ""
+}
+
+// render serializa os objetos como documentos YAML, substituindo o namespace
+// da plataforma, as imagens e lab.resources pelos valores informados
+func render(objects []*unstructured.Unstructured, namespace string, images Images, resources map[string]string, escape func(string) string) ([]byte, error) {
+	var out bytes.Buffer
+	for _, original := range objects {
+		obj := original.DeepCopy()
+		setNamespace(obj, namespace)
+
+		switch {
+		case obj.GetKind() == "Deployment":
+			setImage(obj, map[string]string{"girus-backend": images.Backend, "girus-frontend": images.Frontend}[obj.GetName()])
+		case obj.GetKind() == "ConfigMap" && obj.GetName() == "girus-config":
+			config, _, _ := unstructured.NestedString(obj.Object, "data", "config.yaml")
+			for _, key := range resourceKeys {
+				pattern := regexp.MustCompile(`(?m)^(\s*` + key + `:\s*).*$`)
+				config = pattern.ReplaceAllString(config, "${1}"+resources[key])
+			}
+			if err := unstructured.SetNestedField(obj.Object, config, "data", "config.yaml"); err != nil {
+				return nil, err
+			}
+		}
+
+		doc, err := marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao serializar %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		out.WriteString("---\n")
+		out.WriteString(escape(string(doc)))
+	}
+	return out.Bytes(), nil
+}
+
+// marshal serializa um objeto com apiVersion, kind e metadata no início e os
+// textos de várias linhas dos ConfigMaps em blocos literais, legíveis na revisão
+func marshal(obj *unstructured.Unstructured) ([]byte, error) {
+	if obj.GetKind() == "ConfigMap" {
+		data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+		for key, value := range data {
+			// Espaços no fim das linhas impedem o uso de blocos literais
+			data[key] = trailingSpaces.ReplaceAllString(value, "")
+		}
+		if len(data) > 0 {
+			if err := unstructured.SetNestedStringMap(obj.Object, data, "data"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	keys := []string{"apiVersion", "kind", "metadata"}
+	var rest []string
+	for key := range obj.Object {
+		if !slices.Contains(keys, key) {
+			rest = append(rest, key)
+		}
+	}
+	slices.Sort(rest)
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range append(keys, rest...) {
+		value, ok := obj.Object[key]
+		if !ok {
+			continue
+		}
+		var valueNode yaml.Node
+		if err := valueNode.Encode(value); err != nil {
+			return nil, err
+		}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &valueNode)
+	}
+
+	var doc bytes.Buffer
+	encoder := yaml.NewEncoder(&doc)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	encoder.Close()
+	return doc.Bytes(), nil
+}
+
+// trailingSpaces encontra os espaços no fim de cada linha
+var trailingSpaces = regexp.MustCompile(`(?m)[ \t]+$`)
+
+// setNamespace move os objetos do namespace da plataforma para namespace,
+// incluindo o próprio Namespace e os subjects dos bindings
+func setNamespace(obj *unstructured.Unstructured, namespace string) {
+	if obj.GetKind() == "Namespace" && obj.GetName() == defaultNamespace {
+		obj.SetName(namespace)
+	}
+	if obj.GetNamespace() == defaultNamespace {
+		obj.SetNamespace(namespace)
+	}
+
+	subjects, found, _ := unstructured.NestedSlice(obj.Object, "subjects")
+	if !found {
+		return
+	}
+	for _, subject := range subjects {
+		if s, ok := subject.(map[string]interface{}); ok && s["namespace"] == defaultNamespace {
+			s["namespace"] = namespace
+		}
+	}
+	unstructured.SetNestedSlice(obj.Object, subjects, "subjects")
+}
+
+// setImage altera a imagem do primeiro container de um deployment
+func setImage(obj *unstructured.Unstructured, image string) {
+	if image == "" {
+		return
+	}
+	containers, found, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if !found || len(containers) == 0 {
+		return
+	}
+	if container, ok := containers[0].(map[string]interface{}); ok {
+		container["image"] = image
+	}
+	unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+// resourceMap converte Resources para as chaves do config.yaml
+func resourceMap(r Resources) map[string]string {
+	return map[string]string{
+		"cpuRequest":    fmt.Sprintf("%q", r.CPURequest),
+		"cpuLimit":      fmt.Sprintf("%q", r.CPULimit),
+		"memoryRequest": fmt.Sprintf("%q", r.MemoryRequest),
+		"memoryLimit":   fmt.Sprintf("%q", r.MemoryLimit),
+	}
+}
+
+// noEscape mantém o conteúdo como está
+func noEscape(s string) string { return s }
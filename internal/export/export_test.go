@@ -0,0 +1,106 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultValues(t *testing.T) {
+	values, err := DefaultValues()
+	if err != nil {
+		t.Fatalf("DefaultValues retornou erro: %v", err)
+	}
+	if values.Namespace != "girus" || values.Images.Backend == "" || values.Lab.Resources.MemoryLimit == "" {
+		t.Errorf("valores padrão incompletos: %+v", values)
+	}
+	if len(values.Labs) == 0 {
+		t.Errorf("nenhum template de laboratório encontrado")
+	}
+}
+
+func TestWriteHelm(t *testing.T) {
+	values, err := DefaultValues()
+	if err != nil {
+		t.Fatalf("DefaultValues retornou erro: %v", err)
+	}
+	dir := t.TempDir()
+	if _, err := Write(FormatHelm, dir, values); err != nil {
+		t.Fatalf("Write retornou erro: %v", err)
+	}
+
+	platform, err := os.ReadFile(filepath.Join(dir, "templates", "platform.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"namespace: {{ .Values.namespace }}", "{{ .Values.images.backend | quote }}", "{{ .Values.lab.resources.cpuLimit | quote }}"} {
+		if !strings.Contains(string(platform), want) {
+			t.Errorf("platform.yaml não contém %q", want)
+		}
+	}
+	if strings.Contains(string(platform), "__GIRUS_") {
+		t.Errorf("platform.yaml contém marcadores não substituídos")
+	}
+}
+
+func TestWriteKustomize(t *testing.T) {
+	values, err := DefaultValues()
+	if err != nil {
+		t.Fatalf("DefaultValues retornou erro: %v", err)
+	}
+	values.Namespace = "labs"
+	values.Labs = values.Labs[:1]
+	dir := t.TempDir()
+	if _, err := Write(FormatKustomize, dir, values); err != nil {
+		t.Fatalf("Write retornou erro: %v", err)
+	}
+
+	kustomization, err := os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(kustomization), "labs/"+values.Labs[0]+".yaml") {
+		t.Errorf("kustomization.yaml não lista o laboratório %s", values.Labs[0])
+	}
+	platform, err := os.ReadFile(filepath.Join(dir, "platform.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(platform), "namespace: girus") {
+		t.Errorf("platform.yaml ainda usa o namespace girus")
+	}
+
+	values.Labs = []string{"inexistente"}
+	if _, err := Write(FormatKustomize, t.TempDir(), values); err == nil {
+		t.Errorf("Write deveria rejeitar um laboratório desconhecido")
+	}
+}
+
+func TestWriteKustomizeDigest(t *testing.T) {
+	values, err := DefaultValues()
+	if err != nil {
+		t.Fatalf("DefaultValues retornou erro: %v", err)
+	}
+	values.Images.Backend = "linuxtips/girus-backend@sha256:0a1b2c"
+	values.Images.Frontend = "localhost:5000/girus-frontend:v1@sha256:3d4e5f"
+	dir := t.TempDir()
+	if _, err := Write(FormatKustomize, dir, values); err != nil {
+		t.Fatalf("Write retornou erro: %v", err)
+	}
+
+	kustomization, err := os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `images:
+  - name: linuxtips/girus-backend
+    newDigest: "sha256:0a1b2c"
+  - name: localhost:5000/girus-frontend
+    newTag: "v1"
+    newDigest: "sha256:3d4e5f"
+`
+	if !strings.HasSuffix(string(kustomization), want) {
+		t.Errorf("bloco images inesperado:\n%s", kustomization)
+	}
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"gopkg.in/yaml.v3"
+)
+
+// Marcadores substituídos pelas expressões do Helm após a serialização
+const (
+	namespaceMarker     = "__GIRUS_NAMESPACE__"
+	backendImageMarker  = "__GIRUS_BACKEND_IMAGE__"
+	frontendImageMarker = "__GIRUS_FRONTEND_IMAGE__"
+)
+
+// helmEscaper protege as chaves duplas dos laboratórios (ex: docker inspect
+// --format '{{.State}}') para que não sejam interpretadas pelo Helm
+var helmEscaper = strings.NewReplacer("{{", `{{"{{"}}`, "}}", `{{"}}"}}`)
+
+// helmExpressions traduz os marcadores para as expressões do values.yaml
+var helmExpressions = strings.NewReplacer(
+	namespaceMarker, "{{ .Values.namespace }}",
+	backendImageMarker, "{{ .Values.images.backend | quote }}",
+	frontendImageMarker, "{{ .Values.images.frontend | quote }}",
+	resourceMarker("cpuRequest"), "{{ .Values.lab.resources.cpuRequest | quote }}",
+	resourceMarker("cpuLimit"), "{{ .Values.lab.resources.cpuLimit | quote }}",
+	resourceMarker("memoryRequest"), "{{ .Values.lab.resources.memoryRequest | quote }}",
+	resourceMarker("memoryLimit"), "{{ .Values.lab.resources.memoryLimit | quote }}",
+)
+
+// resourceMarker retorna o marcador de um campo de lab.resources
+func resourceMarker(key string) string {
+	return "__GIRUS_LAB_" + strings.ToUpper(key) + "__"
+}
+
+// helm gera um chart com a plataforma em templates/platform.yaml e cada
+// laboratório em templates/labs/, incluído somente quando listado em .Values.labs
+func (p *platform) helm(values Values) (map[string][]byte, error) {
+	markers := map[string]string{}
+	for _, key := range resourceKeys {
+		markers[key] = resourceMarker(key)
+	}
+	images := Images{Backend: backendImageMarker, Frontend: frontendImageMarker}
+	escape := func(s string) string { return helmExpressions.Replace(helmEscaper.Replace(s)) }
+
+	files := map[string][]byte{}
+
+	platformYAML, err := render(p.objects, namespaceMarker, images, markers, escape)
+	if err != nil {
+		return nil, err
+	}
+	files["templates/platform.yaml"] = platformYAML
+
+	for name, objects := range p.labs {
+		labYAML, err := render(objects, namespaceMarker, images, markers, escape)
+		if err != nil {
+			return nil, err
+		}
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "{{- if has %q .Values.labs }}\n", name)
+		content.Write(labYAML)
+		content.WriteString("{{- end }}\n")
+		files["templates/labs/"+name+".yaml"] = content.Bytes()
+	}
+
+	var valuesYAML bytes.Buffer
+	valuesYAML.WriteString("# Valores do chart do Girus. 'labs' lista os templates de laboratório instalados.\n")
+	encoder := yaml.NewEncoder(&valuesYAML)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(values); err != nil {
+		return nil, fmt.Errorf("falha ao serializar o values.yaml: %w", err)
+	}
+	encoder.Close()
+	files["values.yaml"] = valuesYAML.Bytes()
+
+	appVersion := k8s.ImageTag(values.Images.Backend)
+	if appVersion == "" {
+		appVersion = "latest"
+	}
+	files["Chart.yaml"] = []byte(fmt.Sprintf(`apiVersion: v2
+name: girus
+description: Plataforma de laboratórios interativos Girus
+type: application
+version: 0.1.0
+appVersion: %q
+`, appVersion))
+
+	return files, nil
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// kustomize gera uma base com os valores já aplicados: platform.yaml, um
+// arquivo por laboratório incluído e uma kustomization.yaml com o bloco
+// images, para que as imagens possam ser trocadas em overlays
+func (p *platform) kustomize(values Values) (map[string][]byte, error) {
+	resources := resourceMap(values.Lab.Resources)
+	files := map[string][]byte{}
+
+	platformYAML, err := render(p.objects, values.Namespace, values.Images, resources, noEscape)
+	if err != nil {
+		return nil, err
+	}
+	files["platform.yaml"] = platformYAML
+
+	var kustomization bytes.Buffer
+	kustomization.WriteString("apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n  - platform.yaml\n")
+	for _, name := range values.Labs {
+		labYAML, err := render(p.labs[name], values.Namespace, values.Images, resources, noEscape)
+		if err != nil {
+			return nil, err
+		}
+		files["labs/"+name+".yaml"] = labYAML
+		fmt.Fprintf(&kustomization, "  - labs/%s.yaml\n", name)
+	}
+
+	kustomization.WriteString("images:\n")
+	for _, image := range []string{values.Images.Backend, values.Images.Frontend} {
+		name, tag, digest := splitImage(image)
+		fmt.Fprintf(&kustomization, "  - name: %s\n", name)
+		if tag != "" {
+			fmt.Fprintf(&kustomization, "    newTag: %q\n", tag)
+		}
+		if digest != "" {
+			fmt.Fprintf(&kustomization, "    newDigest: %q\n", digest)
+		}
+	}
+	files["kustomization.yaml"] = kustomization.Bytes()
+
+	return files, nil
+}
+
+// splitImage separa o nome, a tag e o digest de uma referência de imagem
+// (repo, repo:tag, repo@sha256:... ou repo:tag@sha256:...)
+func splitImage(image string) (name, tag, digest string) {
+	name = image
+	if idx := strings.Index(name, "@"); idx >= 0 {
+		name, digest = name[:idx], name[idx+1:]
+	}
+	slash := strings.LastIndex(name, "/")
+	if idx := strings.LastIndex(name, ":"); idx > slash {
+		name, tag = name[:idx], name[idx+1:]
+	}
+	return name, tag, digest
+}
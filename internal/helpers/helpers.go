@@ -1,31 +1,49 @@
 package helpers
 
 import (
-	"bytes"
 	"fmt"
+	"net"
 	"os/exec"
 	"runtime"
-	"strings"
-	"time"
+	"slices"
 
-	"github.com/schollz/progressbar/v3"
+	"github.com/badtuxx/girus-cli/internal/common"
 )
 
-type ProgressBarConfig struct {
-	Total            int
-	Description      string
-	Width            int
-	ShowBytes        bool
-	SetPredictTime   bool
-	Throttle         time.Duration
-	RenderBlankState bool
-	SpinnerType      int
+// PortInUse verifica se uma porta do host está em uso tentando abri-la
+func PortInUse(port int) bool {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return true
+	}
+	listener.Close()
+	return false
 }
 
-// portInUse verifica se uma porta está em uso
-func PortInUse(port int) bool {
-	checkCmd := exec.Command("lsof", "-i", fmt.Sprintf(":%d", port))
-	return checkCmd.Run() == nil
+// FreePort retorna port quando ela está livre ou, caso contrário, a próxima
+// porta livre acima dela, ignorando as portas em exclude
+func FreePort(port int, exclude ...int) (int, error) {
+	for candidate := port; candidate < port+100 && candidate <= 65535; candidate++ {
+		if slices.Contains(exclude, candidate) || PortInUse(candidate) {
+			continue
+		}
+		return candidate, nil
+	}
+	return 0, fmt.Errorf("nenhuma porta livre entre %d e %d", port, min(port+99, 65535))
+}
+
+// ChoosePorts mantém as portas pedidas que estão livres e troca as ocupadas
+// pela próxima porta livre, sem usar a mesma porta para o frontend e o backend
+func ChoosePorts(requested common.HostPorts) (common.HostPorts, error) {
+	frontend, err := FreePort(requested.Frontend, requested.Backend)
+	if err != nil {
+		return common.HostPorts{}, err
+	}
+	backend, err := FreePort(requested.Backend, frontend)
+	if err != nil {
+		return common.HostPorts{}, err
+	}
+	return common.HostPorts{Frontend: frontend, Backend: backend}, nil
 }
 
 // openBrowser abre o navegador com a URL especificada
@@ -45,72 +63,3 @@ func OpenBrowser(url string) error {
 
 	return cmd.Start()
 }
-
-// checkPortForwardNeeded verifica se o port-forward para o backend precisa ser reconfigurado
-func CheckPortForwardNeeded() bool {
-	backendNeeded := false
-	frontendNeeded := false
-
-	// Verificar se a porta 8080 (backend) está em uso
-	backendPortCmd := exec.Command("lsof", "-i", ":8080")
-	if backendPortCmd.Run() != nil {
-		// Porta 8080 não está em uso, precisamos de port-forward
-		backendNeeded = true
-	} else {
-		// Porta está em uso, mas precisamos verificar se é o kubectl port-forward e se está funcional
-		// Verificar se o processo é kubectl port-forward
-		backendProcessCmd := exec.Command("sh", "-c", "ps -eo pid,cmd | grep 'kubectl port-forward' | grep '8080' | grep -v grep")
-		if backendProcessCmd.Run() != nil {
-			// Não encontrou processo de port-forward ativo ou válido
-			backendNeeded = true
-		} else {
-			// Verificar se a conexão com o backend está funcionando
-			backendHealthCmd := exec.Command("curl", "-s", "--head", "--max-time", "2", "http://localhost:8080/api/v1/health")
-			backendNeeded = backendHealthCmd.Run() != nil // Retorna true (precisa de port-forward) se o comando falhar
-		}
-	}
-
-	// Verificar se a porta 8000 (frontend) está em uso
-	frontendPortCmd := exec.Command("lsof", "-i", ":8000")
-	if frontendPortCmd.Run() != nil {
-		// Porta 8000 não está em uso, precisamos de port-forward
-		frontendNeeded = true
-	} else {
-		// Porta está em uso, mas precisamos verificar se é o kubectl port-forward e se está funcional
-		// Verificar se o processo é kubectl port-forward
-		frontendProcessCmd := exec.Command("sh", "-c", "ps -eo pid,cmd | grep 'kubectl port-forward' | grep '8000' | grep -v grep")
-		if frontendProcessCmd.Run() != nil {
-			// Não encontrou processo de port-forward ativo ou válido
-			frontendNeeded = true
-		} else {
-			// Verificar se a conexão com o frontend está funcionando
-			frontendCheckCmd := exec.Command("curl", "-s", "--max-time", "2", "-o", "/dev/null", "-w", "%{http_code}", "http://localhost:8000")
-			var out bytes.Buffer
-			frontendCheckCmd.Stdout = &out
-			if frontendCheckCmd.Run() != nil {
-				frontendNeeded = true
-			} else {
-				statusCode := strings.TrimSpace(out.String())
-				frontendNeeded = (statusCode != "200" && statusCode != "301" && statusCode != "302")
-			}
-		}
-	}
-
-	// Se qualquer um dos serviços precisar de port-forward, retorne true
-	return backendNeeded || frontendNeeded
-}
-
-func CreateProgressBar(config ProgressBarConfig) *progressbar.ProgressBar {
-	return progressbar.NewOptions(
-		config.Total,
-		progressbar.OptionSetDescription(config.Description),
-		progressbar.OptionSetWidth(config.Width),
-		progressbar.OptionShowBytes(config.ShowBytes),
-		progressbar.OptionSetPredictTime(config.SetPredictTime),
-		progressbar.OptionThrottle(config.Throttle*time.Millisecond),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSpinnerType(config.SpinnerType),
-		progressbar.OptionSetRenderBlankState(config.RenderBlankState),
-		progressbar.OptionFullWidth(),
-	)
-}
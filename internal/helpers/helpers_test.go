@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+)
+
+func TestFreePort(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("não foi possível abrir uma porta: %v", err)
+	}
+	defer listener.Close()
+	busy := listener.Addr().(*net.TCPAddr).Port
+
+	port, err := FreePort(busy)
+	if err != nil {
+		t.Fatalf("FreePort retornou erro: %v", err)
+	}
+	if port == busy {
+		t.Errorf("porta ocupada %d não deveria ser escolhida", busy)
+	}
+
+	excluded, err := FreePort(busy, port)
+	if err != nil {
+		t.Fatalf("FreePort retornou erro: %v", err)
+	}
+	if excluded == port || excluded == busy {
+		t.Errorf("porta excluída %d não deveria ser escolhida", excluded)
+	}
+}
+
+func TestConfirmNonInteractive(t *testing.T) {
+	t.Setenv(common.NonInteractiveEnv, "1")
+
+	// Sem --yes, as perguntas recebem a resposta padrão
+	if Confirm("Deseja excluir?", false) {
+		t.Errorf("o modo não interativo não deveria confirmar uma pergunta com padrão não")
+	}
+	if !Confirm("Deseja continuar?", true) {
+		t.Errorf("o modo não interativo deveria aceitar uma pergunta com padrão sim")
+	}
+	if Interactive() {
+		t.Errorf("o modo não interativo não deveria perguntar")
+	}
+
+	common.SetAssumeYes(true)
+	defer common.SetAssumeYes(false)
+	if !Confirm("Deseja excluir?", false) {
+		t.Errorf("--yes deveria confirmar todas as perguntas")
+	}
+}
+
+func TestAskNonInteractive(t *testing.T) {
+	t.Setenv(common.NonInteractiveEnv, "1")
+
+	// A pergunta não é exibida: a resposta é o valor padrão
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	answer := Ask("Título", "padrão")
+	os.Stdout = stdout
+	w.Close()
+	printed, _ := io.ReadAll(r)
+
+	if answer != "padrão" {
+		t.Errorf("Ask = %q, esperado o valor padrão", answer)
+	}
+	if len(printed) != 0 {
+		t.Errorf("Ask não deveria exibir nada no modo não interativo, exibiu %q", printed)
+	}
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"golang.org/x/term"
+)
+
+// stdin é compartilhado por todas as perguntas para não perder entrada já lida
+var stdin = bufio.NewReader(os.Stdin)
+
+// Interactive indica se é possível perguntar ao usuário: o modo não
+// interativo está desligado e a entrada padrão é um terminal
+func Interactive() bool {
+	return !common.NonInteractive() && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Confirm faz uma pergunta de sim/não, com defaultYes como resposta para
+// Enter. Com --yes a resposta é sim; no modo não interativo
+// (--non-interactive ou GIRUS_NONINTERACTIVE) é a resposta padrão, e sem um
+// terminal na entrada padrão é não, o padrão seguro.
+func Confirm(question string, defaultYes bool) bool {
+	options := common.T("[s/N]", "[s/N]")
+	if defaultYes {
+		options = common.T("[S/n]", "[S/n]")
+	}
+	fmt.Printf("%s %s: ", question, options)
+
+	if common.AssumeYes() {
+		fmt.Println(common.T("s (--yes)", "s (--yes)"))
+		return true
+	}
+	if common.NonInteractive() {
+		if defaultYes {
+			fmt.Println(common.T("s (modo não interativo)", "s (modo no interactivo)"))
+		} else {
+			fmt.Println(common.T("n (modo não interativo; use --yes para confirmar)", "n (modo no interactivo; use --yes para confirmar)"))
+		}
+		return defaultYes
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Println(common.T("n (sem terminal interativo; use --yes para confirmar)", "n (sin terminal interactivo; use --yes para confirmar)"))
+		return false
+	}
+
+	response, _ := stdin.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "":
+		return defaultYes
+	case "s", "sim", "si", "sí", "y", "yes":
+		return true
+	}
+	return false
+}
+
+// Ask faz uma pergunta de texto livre, com defaultValue como resposta para
+// Enter. Sem um terminal interativo, ou no modo não interativo, nada é
+// exibido e a resposta é o valor padrão.
+func Ask(question, defaultValue string) string {
+	if !Interactive() {
+		return defaultValue
+	}
+
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+
+	response, _ := stdin.ReadString('\n')
+	if response = strings.TrimSpace(response); response != "" {
+		return response
+	}
+	return defaultValue
+}
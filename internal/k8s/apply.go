@@ -0,0 +1,208 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+const (
+	// FieldManager identifica o Girus como dono dos campos aplicados com server-side apply
+	FieldManager = "girus"
+
+	// applyWorkers limita quantos objetos são aplicados ao mesmo tempo
+	applyWorkers = 8
+)
+
+// ApplyResult é o resultado da aplicação de um objeto
+type ApplyResult struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Err       error
+}
+
+// String retorna o objeto no formato Kind/nome
+func (r ApplyResult) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+// Applier aplica objetos no cluster com o cliente dinâmico e server-side
+// apply, sem depender do kubectl
+type Applier struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+}
+
+// NewApplier cria um Applier para um contexto do kubeconfig
+func NewApplier(kubeContext string) (*Applier, error) {
+	config, err := RestConfig(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar configuração para o contexto %s: %w", kubeContext, err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar o cliente dinâmico: %w", err)
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar o cliente de descoberta: %w", err)
+	}
+
+	return &Applier{
+		client: client,
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+// Apply aplica os objetos em fases (veja applyPhases); dentro de cada fase os
+// objetos são aplicados em paralelo. onResult, quando informado, é chamado
+// após cada objeto. Retorna os resultados na ordem dos objetos.
+func (a *Applier) Apply(ctx context.Context, objects []*unstructured.Unstructured, onResult func(ApplyResult)) []ApplyResult {
+	results := make([]ApplyResult, len(objects))
+	index := make(map[*unstructured.Unstructured]int, len(objects))
+	for i, obj := range objects {
+		index[obj] = i
+	}
+
+	var mu sync.Mutex
+	for _, phase := range applyPhases(objects) {
+		jobs := make(chan *unstructured.Unstructured)
+		var wg sync.WaitGroup
+		for range min(applyWorkers, len(phase)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for obj := range jobs {
+					result := a.applyObject(ctx, obj)
+					mu.Lock()
+					results[index[obj]] = result
+					if onResult != nil {
+						onResult(result)
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		for _, obj := range phase {
+			jobs <- obj
+		}
+		close(jobs)
+		wg.Wait()
+	}
+
+	return results
+}
+
+// applyObject aplica um único objeto com server-side apply
+func (a *Applier) applyObject(ctx context.Context, obj *unstructured.Unstructured) ApplyResult {
+	result := ApplyResult{Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}
+
+	resource, namespace, err := a.resource(obj)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Namespace = namespace
+
+	_, err = resource.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
+	if err != nil {
+		result.Err = fmt.Errorf("falha ao aplicar %s: %w", result.String(), err)
+	}
+	return result
+}
+
+// Diff compara o objeto implantado com o resultado de aplicá-lo com
+// server-side apply em dry-run, com o mesmo gerenciador de campos de Apply,
+// e retorna a diferença em formato unificado, vazia quando nada mudaria
+func (a *Applier) Diff(ctx context.Context, obj *unstructured.Unstructured) (string, error) {
+	resource, _, err := a.resource(obj)
+	if err != nil {
+		return "", err
+	}
+
+	live := map[string]any{}
+	current, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case err == nil:
+		live = diffFields(current)
+	case !apierrors.IsNotFound(err):
+		return "", fmt.Errorf("falha ao obter %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	merged, err := resource.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: FieldManager, Force: true, DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return "", fmt.Errorf("falha ao comparar %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	applied := diffFields(merged)
+	if equality.Semantic.DeepEqual(live, applied) {
+		return "", nil
+	}
+	return diff.Diff(live, applied), nil
+}
+
+// diffFields retorna o conteúdo do objeto sem os campos que mudam a cada
+// apply mesmo sem alteração no manifesto
+func diffFields(obj *unstructured.Unstructured) map[string]any {
+	content := obj.DeepCopy().Object
+	for _, field := range []string{"managedFields", "resourceVersion", "generation"} {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+	return content
+}
+
+// resource retorna o cliente do tipo do objeto e o namespace efetivo dele,
+// vazio para objetos de escopo de cluster
+func (a *Applier) resource(obj *unstructured.Unstructured) (dynamic.ResourceInterface, string, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, "", fmt.Errorf("tipo %s desconhecido pelo cluster: %w", gvk.String(), err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return a.client.Resource(mapping.Resource), obj.GetNamespace(), nil
+	}
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return a.client.Resource(mapping.Resource).Namespace(namespace), namespace, nil
+}
+
+// applyPhases agrupa os objetos na ordem em que precisam existir: namespaces
+// e CRDs primeiro, depois os demais objetos e por último os workloads, para
+// que os pods já encontrem os ConfigMaps e permissões ao iniciar
+func applyPhases(objects []*unstructured.Unstructured) [][]*unstructured.Unstructured {
+	phases := make([][]*unstructured.Unstructured, 3)
+	for _, obj := range objects {
+		switch obj.GetKind() {
+		case "Namespace", "CustomResourceDefinition":
+			phases[0] = append(phases[0], obj)
+		case "Deployment", "StatefulSet", "DaemonSet", "Job", "Pod":
+			phases[2] = append(phases[2], obj)
+		default:
+			phases[1] = append(phases[1], obj)
+		}
+	}
+
+	var nonEmpty [][]*unstructured.Unstructured
+	for _, phase := range phases {
+		if len(phase) > 0 {
+			nonEmpty = append(nonEmpty, phase)
+		}
+	}
+	return nonEmpty
+}
@@ -0,0 +1,62 @@
+package k8s
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestApplyPhases(t *testing.T) {
+	objects, err := EmbeddedObjects()
+	if err != nil {
+		t.Fatalf("EmbeddedObjects retornou erro: %v", err)
+	}
+
+	phases := applyPhases(objects)
+	if len(phases) != 3 {
+		t.Fatalf("esperadas 3 fases, obtidas %d", len(phases))
+	}
+	if phases[0][0].GetKind() != "Namespace" {
+		t.Errorf("a primeira fase deveria conter o namespace, obtido %s", phases[0][0].GetKind())
+	}
+	for _, obj := range phases[2] {
+		if obj.GetKind() != "Deployment" {
+			t.Errorf("a última fase deveria conter apenas workloads, obtido %s/%s", obj.GetKind(), obj.GetName())
+		}
+	}
+
+	total := 0
+	for _, phase := range phases {
+		total += len(phase)
+	}
+	if total != len(objects) {
+		t.Errorf("esperados %d objetos nas fases, obtidos %d", len(objects), total)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"kind": "ConfigMap",
+		"metadata": map[string]any{
+			"name":            "girus-config",
+			"resourceVersion": "42",
+			"generation":      int64(3),
+			"managedFields":   []any{map[string]any{"manager": "girus"}},
+		},
+		"data": map[string]any{"chave": "valor"},
+	}}
+
+	fields := diffFields(obj)
+	metadata := fields["metadata"].(map[string]any)
+	for _, field := range []string{"resourceVersion", "generation", "managedFields"} {
+		if _, found := metadata[field]; found {
+			t.Errorf("diffFields deveria remover metadata.%s", field)
+		}
+	}
+	if metadata["name"] != "girus-config" || fields["data"] == nil {
+		t.Errorf("diffFields removeu campos do manifesto: %v", fields)
+	}
+	if _, found := obj.Object["metadata"].(map[string]any)["resourceVersion"]; !found {
+		t.Errorf("diffFields não deveria alterar o objeto original")
+	}
+}
@@ -0,0 +1,221 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// IngressNginxManifestURL é o manifesto do ingress-nginx preparado para o Kind
+	IngressNginxManifestURL = "https://raw.githubusercontent.com/kubernetes/ingress-nginx/controller-v1.12.1/deploy/static/provider/kind/deploy.yaml"
+
+	ingressNginxNamespace  = "ingress-nginx"
+	ingressNginxController = "ingress-nginx-controller"
+)
+
+// girusServices são os serviços publicados pelos modos nodeport e ingress,
+// com o NodePort e os caminhos do Ingress de cada um
+var girusServices = []struct {
+	name     string
+	port     int64
+	nodePort int64
+	paths    []string
+}{
+	{name: "girus-backend", port: 8080, nodePort: cluster.BackendNodePort, paths: []string{"/api", "/ws"}},
+	{name: "girus-frontend", port: 80, nodePort: cluster.FrontendNodePort, paths: []string{"/"}},
+}
+
+// ExposeObjects ajusta os objetos do Girus para o modo de acesso: no modo
+// nodeport os serviços viram NodePort com portas fixas e no modo ingress são
+// acrescentados os Ingress do frontend e do backend
+func ExposeObjects(objects []*unstructured.Unstructured, mode, provider string) ([]*unstructured.Unstructured, error) {
+	switch mode {
+	case cluster.ExposeNodePort:
+		for _, obj := range objects {
+			if obj.GetKind() != "Service" {
+				continue
+			}
+			for _, service := range girusServices {
+				if obj.GetName() != service.name {
+					continue
+				}
+				if err := setNodePort(obj, service.nodePort); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case cluster.ExposeIngress:
+		ingressClass := "nginx"
+		if provider == cluster.ProviderK3d {
+			ingressClass = "traefik"
+		}
+		for _, service := range girusServices {
+			objects = append(objects, ingressObject(service.name, service.port, service.paths, ingressClass))
+		}
+	}
+	return objects, nil
+}
+
+// setNodePort transforma o serviço em NodePort com a porta fixa informada
+func setNodePort(obj *unstructured.Unstructured, nodePort int64) error {
+	ports, _, _ := unstructured.NestedSlice(obj.Object, "spec", "ports")
+	if len(ports) == 0 {
+		return fmt.Errorf("serviço %s sem portas", obj.GetName())
+	}
+	if port, ok := ports[0].(map[string]interface{}); ok {
+		port["nodePort"] = nodePort
+	}
+	if err := unstructured.SetNestedSlice(obj.Object, ports, "spec", "ports"); err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(obj.Object, "NodePort", "spec", "type")
+}
+
+// ingressObject cria o Ingress que encaminha os caminhos ao serviço
+func ingressObject(service string, port int64, paths []string, ingressClass string) *unstructured.Unstructured {
+	var httpPaths []interface{}
+	for _, path := range paths {
+		httpPaths = append(httpPaths, map[string]interface{}{
+			"path":     path,
+			"pathType": "Prefix",
+			"backend": map[string]interface{}{
+				"service": map[string]interface{}{
+					"name": service,
+					"port": map[string]interface{}{"number": port},
+				},
+			},
+		})
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata": map[string]interface{}{
+			"name":      service,
+			"namespace": "girus",
+			"annotations": map[string]interface{}{
+				// Conexões WebSocket dos terminais dos laboratórios
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "86400",
+				"nginx.ingress.kubernetes.io/proxy-send-timeout": "86400",
+			},
+		},
+		"spec": map[string]interface{}{
+			"ingressClassName": ingressClass,
+			"rules": []interface{}{
+				map[string]interface{}{"http": map[string]interface{}{"paths": httpPaths}},
+			},
+		},
+	}}
+}
+
+// InstallIngressController instala o ingress-nginx no Kind e aguarda o
+// controller ficar pronto, pois seu webhook valida os Ingress aplicados em
+// seguida. No k3d o Traefik já vem instalado.
+func InstallIngressController(provider string, timeout time.Duration) error {
+	if provider == cluster.ProviderK3d {
+		return nil
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(IngressNginxManifestURL)
+	if err != nil {
+		return fmt.Errorf("falha ao baixar o manifesto do ingress-nginx: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("falha ao baixar o manifesto do ingress-nginx: status %d", resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("falha ao ler o manifesto do ingress-nginx: %w", err)
+	}
+	objects, err := ParseManifest(content)
+	if err != nil {
+		return err
+	}
+
+	applier, err := NewApplier(common.KubeContext())
+	if err != nil {
+		return err
+	}
+	for _, result := range applier.Apply(context.Background(), objects, nil) {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+
+	k8sClient, err := NewKubernetesClient()
+	if err != nil {
+		return err
+	}
+	return k8sClient.WaitForDeploymentAvailable(context.Background(), ingressNginxNamespace, ingressNginxController, timeout)
+}
+
+// WaitForDeploymentAvailable aguarda todas as réplicas de um deployment ficarem disponíveis
+func (k *KubernetesClient) WaitForDeploymentAvailable(ctx context.Context, namespace, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		deployment, err := k.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil && deployment.Spec.Replicas != nil && deployment.Status.AvailableReplicas >= *deployment.Spec.Replicas && deployment.Status.ObservedGeneration >= deployment.Generation {
+			return nil
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("falha ao verificar o deployment %s: %w", name, err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout ao aguardar o deployment %s/%s", namespace, name)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// ExposeMode detecta o modo de acesso do Girus no cluster: ingress quando
+// existe o Ingress do frontend, nodeport quando o serviço do frontend é
+// NodePort e port-forward nos demais casos
+func (k *KubernetesClient) ExposeMode(ctx context.Context) string {
+	if _, err := k.clientset.NetworkingV1().Ingresses("girus").Get(ctx, "girus-frontend", metav1.GetOptions{}); err == nil {
+		return cluster.ExposeIngress
+	}
+	service, err := k.clientset.CoreV1().Services("girus").Get(ctx, "girus-frontend", metav1.GetOptions{})
+	if err == nil && service.Spec.Type == "NodePort" {
+		return cluster.ExposeNodePort
+	}
+	return cluster.ExposePortForward
+}
+
+// AccessURL retorna a URL do frontend no host para o modo de acesso, com a
+// porta escolhida para o cluster selecionado
+func AccessURL(mode string) string {
+	if mode == cluster.ExposeIngress {
+		return "http://localhost"
+	}
+	return fmt.Sprintf("http://localhost:%d", common.Ports().Frontend)
+}
+
+// ClusterAccessURL retorna a URL do frontend para o modo de acesso detectado
+// no cluster selecionado
+func ClusterAccessURL() string {
+	mode := cluster.ExposePortForward
+	if client, err := NewKubernetesClient(); err == nil {
+		mode = client.ExposeMode(context.Background())
+	}
+	return AccessURL(mode)
+}
+
+// BackendURL retorna a URL do backend no host para o modo de acesso, com a
+// porta escolhida para o cluster selecionado
+func BackendURL(mode string) string {
+	if mode == cluster.ExposeIngress {
+		return "http://localhost"
+	}
+	return fmt.Sprintf("http://localhost:%d", common.Ports().Backend)
+}
@@ -0,0 +1,138 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ClusterInfo resume o estado de um cluster e da instalação do Girus nele
+type ClusterInfo struct {
+	Nodes             int       `json:"nodes"`
+	KubernetesVersion string    `json:"kubernetesVersion"`
+	Created           time.Time `json:"created"`
+	GirusInstalled    bool      `json:"girusInstalled"`
+	BackendImage      string    `json:"backendImage,omitempty"`
+	Pods              []PodInfo `json:"pods,omitempty"`
+}
+
+// PodInfo resume o estado de um pod do Girus
+type PodInfo struct {
+	Name  string `json:"name"`
+	Phase string `json:"phase"`
+	Ready bool   `json:"ready"`
+}
+
+// BackendVersion retorna a tag da imagem do backend do Girus
+func (c ClusterInfo) BackendVersion() string {
+	return ImageTag(c.BackendImage)
+}
+
+// ImageTag extrai a tag de uma referência de imagem ("" quando não há tag)
+func ImageTag(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if idx := strings.Index(name, "@"); idx >= 0 {
+		name = name[:idx]
+	}
+	if idx := strings.LastIndex(name, ":"); idx >= 0 {
+		return name[idx+1:]
+	}
+	return ""
+}
+
+// NewKubernetesClientForContext cria um cliente para um contexto específico do
+// kubeconfig, sem alterar o current-context. Um timeout não nulo limita cada
+// requisição, evitando que clusters parados travem o comando.
+func NewKubernetesClientForContext(kubeContext string, timeout time.Duration) (*KubernetesClient, error) {
+	config, err := RestConfig(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar configuração para o contexto %s: %w", kubeContext, err)
+	}
+	config.Timeout = timeout
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar o clientset: %w", err)
+	}
+
+	return &KubernetesClient{clientset: clientset}, nil
+}
+
+// ClusterInfo consulta os nós, a versão do Kubernetes, a idade do cluster
+// (criação do namespace kube-system) e o estado do Girus no namespace girus
+func (k *KubernetesClient) ClusterInfo(ctx context.Context) (*ClusterInfo, error) {
+	info := &ClusterInfo{}
+
+	version, err := k.ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	info.KubernetesVersion = version
+
+	nodes, err := k.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar os nós: %w", err)
+	}
+	info.Nodes = len(nodes.Items)
+
+	kubeSystem, err := k.clientset.CoreV1().Namespaces().Get(ctx, "kube-system", metav1.GetOptions{})
+	if err == nil {
+		info.Created = kubeSystem.CreationTimestamp.Time
+	}
+
+	_, err = k.clientset.CoreV1().Namespaces().Get(ctx, "girus", metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return info, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao verificar o namespace girus: %w", err)
+	}
+	info.GirusInstalled = true
+
+	backend, err := k.clientset.AppsV1().Deployments("girus").Get(ctx, "girus-backend", metav1.GetOptions{})
+	if err == nil && len(backend.Spec.Template.Spec.Containers) > 0 {
+		info.BackendImage = backend.Spec.Template.Spec.Containers[0].Image
+	}
+
+	pods, err := k.clientset.CoreV1().Pods("girus").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar os pods do Girus: %w", err)
+	}
+	for _, pod := range pods.Items {
+		info.Pods = append(info.Pods, PodInfo{
+			Name:  pod.Name,
+			Phase: string(pod.Status.Phase),
+			Ready: isPodReady(pod),
+		})
+	}
+
+	return info, nil
+}
+
+// isPodReady verifica a condição Ready do pod
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// DeploymentImage retorna a imagem do primeiro container de um deployment
+func (k *KubernetesClient) DeploymentImage(ctx context.Context, namespace, name string) (string, error) {
+	deployment, err := k.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if len(deployment.Spec.Template.Spec.Containers) == 0 {
+		return "", fmt.Errorf("deployment %s sem containers", name)
+	}
+	return deployment.Spec.Template.Spec.Containers[0].Image, nil
+}
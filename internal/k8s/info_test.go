@@ -0,0 +1,18 @@
+package k8s
+
+import "testing"
+
+func TestImageTag(t *testing.T) {
+	cases := map[string]string{
+		"linuxtips/girus-backend:v0.3.0":                "v0.3.0",
+		"localhost:5000/girus-backend:latest":           "latest",
+		"localhost:5000/girus-backend":                  "",
+		"ghcr.io/badtuxx/girus-frontend@sha256:0a1b":    "",
+		"ghcr.io/badtuxx/girus-frontend:v1@sha256:0a1b": "v1",
+	}
+	for image, want := range cases {
+		if got := ImageTag(image); got != want {
+			t.Errorf("ImageTag(%q) = %q, esperado %q", image, got, want)
+		}
+	}
+}
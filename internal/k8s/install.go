@@ -0,0 +1,224 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/templates"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// ManagedByLabel marca os objetos criados pelo 'girus install'
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue é o valor de ManagedByLabel nos objetos do Girus
+	ManagedByValue = "girus"
+)
+
+// InstallConflict descreve um objeto já existente no cluster que não foi
+// criado pelo Girus e que seria sobrescrito pela instalação
+type InstallConflict struct {
+	Kind string
+	Name string
+}
+
+// ParseManifest separa um manifesto YAML com vários documentos em objetos,
+// ignorando documentos vazios
+func ParseManifest(data []byte) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		var content map[string]interface{}
+		if err := decoder.Decode(&content); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("falha ao interpretar o manifesto: %w", err)
+		}
+		if len(content) == 0 {
+			continue
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: content})
+	}
+
+	return objects, nil
+}
+
+// EmbeddedObjects retorna os objetos dos manifestos embutidos: a
+// infraestrutura de defaultDeployment.yaml seguida dos templates de laboratório
+func EmbeddedObjects() ([]*unstructured.Unstructured, error) {
+	names, err := EmbeddedManifests()
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, name := range names {
+		content, err := templates.GetManifest(name)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao carregar o manifesto %s: %w", name, err)
+		}
+		parsed, err := ParseManifest(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		objects = append(objects, parsed...)
+	}
+
+	return objects, nil
+}
+
+// EmbeddedManifests retorna os nomes dos manifestos embutidos no idioma
+// selecionado, na ordem em que são aplicados
+func EmbeddedManifests() ([]string, error) {
+	manifestFiles, err := templates.ListManifests()
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar os manifestos embutidos: %w", err)
+	}
+
+	// defaultDeployment.yaml cria o namespace, portanto precisa ser aplicado primeiro
+	names := []string{"defaultDeployment.yaml"}
+	for _, name := range manifestFiles {
+		if name != "defaultDeployment.yaml" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// InstallObjects retorna os objetos aplicados pelo 'girus install': os
+// manifestos embutidos marcados com ManagedByLabel
+func InstallObjects() ([]*unstructured.Unstructured, error) {
+	objects, err := EmbeddedObjects()
+	if err != nil {
+		return nil, err
+	}
+	MarkManaged(objects)
+	return objects, nil
+}
+
+// MarkManaged adiciona ManagedByLabel aos objetos
+func MarkManaged(objects []*unstructured.Unstructured) {
+	for _, obj := range objects {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[ManagedByLabel] = ManagedByValue
+		obj.SetLabels(labels)
+	}
+}
+
+// CurrentContext retorna o contexto efetivo do cluster selecionado,
+// resolvendo o current-context do kubeconfig quando nenhum foi informado
+func CurrentContext() (string, error) {
+	if kubeContext := common.KubeContext(); kubeContext != "" {
+		return kubeContext, nil
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = common.Kubeconfig()
+	rawConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		return "", fmt.Errorf("falha ao ler o kubeconfig: %w", err)
+	}
+	if rawConfig.CurrentContext == "" {
+		return "", fmt.Errorf("o kubeconfig não define um current-context; informe --context")
+	}
+	return rawConfig.CurrentContext, nil
+}
+
+// ServerVersion retorna a versão do Kubernetes do cluster, confirmando que a API está acessível
+func (k *KubernetesClient) ServerVersion() (string, error) {
+	version, err := k.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("falha ao acessar a API do cluster: %w", err)
+	}
+	return version.GitVersion, nil
+}
+
+// FindInstallConflicts verifica os objetos de escopo de cluster (namespaces e
+// RBAC) que já existem sem o rótulo do Girus. Objetos com namespace ficam
+// protegidos pela verificação do próprio namespace.
+func (k *KubernetesClient) FindInstallConflicts(ctx context.Context, objects []*unstructured.Unstructured) ([]InstallConflict, error) {
+	var conflicts []InstallConflict
+
+	for _, obj := range objects {
+		var existing metav1.Object
+		var err error
+
+		switch obj.GetKind() {
+		case "Namespace":
+			existing, err = k.clientset.CoreV1().Namespaces().Get(ctx, obj.GetName(), metav1.GetOptions{})
+		case "ClusterRole":
+			existing, err = k.clientset.RbacV1().ClusterRoles().Get(ctx, obj.GetName(), metav1.GetOptions{})
+		case "ClusterRoleBinding":
+			existing, err = k.clientset.RbacV1().ClusterRoleBindings().Get(ctx, obj.GetName(), metav1.GetOptions{})
+		default:
+			continue
+		}
+
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("falha ao verificar %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if existing.GetLabels()[ManagedByLabel] != ManagedByValue {
+			conflicts = append(conflicts, InstallConflict{Kind: obj.GetKind(), Name: obj.GetName()})
+		}
+	}
+
+	return conflicts, nil
+}
+
+// Uninstall remove apenas os objetos de escopo de cluster marcados com
+// ManagedByLabel. Os objetos com namespace são removidos junto com o namespace.
+// Retorna a lista dos objetos excluídos no formato Kind/nome.
+func (k *KubernetesClient) Uninstall(ctx context.Context) ([]string, error) {
+	selector := metav1.ListOptions{LabelSelector: ManagedByLabel + "=" + ManagedByValue}
+	var removed []string
+
+	bindings, err := k.clientset.RbacV1().ClusterRoleBindings().List(ctx, selector)
+	if err != nil {
+		return removed, fmt.Errorf("falha ao listar ClusterRoleBindings: %w", err)
+	}
+	for _, binding := range bindings.Items {
+		if err := k.clientset.RbacV1().ClusterRoleBindings().Delete(ctx, binding.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return removed, fmt.Errorf("falha ao excluir ClusterRoleBinding %s: %w", binding.Name, err)
+		}
+		removed = append(removed, "ClusterRoleBinding/"+binding.Name)
+	}
+
+	roles, err := k.clientset.RbacV1().ClusterRoles().List(ctx, selector)
+	if err != nil {
+		return removed, fmt.Errorf("falha ao listar ClusterRoles: %w", err)
+	}
+	for _, role := range roles.Items {
+		if err := k.clientset.RbacV1().ClusterRoles().Delete(ctx, role.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return removed, fmt.Errorf("falha ao excluir ClusterRole %s: %w", role.Name, err)
+		}
+		removed = append(removed, "ClusterRole/"+role.Name)
+	}
+
+	namespaces, err := k.clientset.CoreV1().Namespaces().List(ctx, selector)
+	if err != nil {
+		return removed, fmt.Errorf("falha ao listar namespaces: %w", err)
+	}
+	for _, namespace := range namespaces.Items {
+		if err := k.clientset.CoreV1().Namespaces().Delete(ctx, namespace.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return removed, fmt.Errorf("falha ao excluir o namespace %s: %w", namespace.Name, err)
+		}
+		removed = append(removed, "Namespace/"+namespace.Name)
+	}
+
+	return removed, nil
+}
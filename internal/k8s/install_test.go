@@ -0,0 +1,26 @@
+package k8s
+
+import "testing"
+
+func TestInstallObjects(t *testing.T) {
+	objects, err := InstallObjects()
+	if err != nil {
+		t.Fatalf("InstallObjects retornou erro: %v", err)
+	}
+	if len(objects) == 0 || objects[0].GetKind() != "Namespace" || objects[0].GetName() != "girus" {
+		t.Fatalf("o primeiro objeto deveria ser o namespace girus")
+	}
+
+	labTemplates := 0
+	for _, obj := range objects {
+		if obj.GetLabels()[ManagedByLabel] != ManagedByValue {
+			t.Errorf("%s/%s sem o rótulo %s", obj.GetKind(), obj.GetName(), ManagedByLabel)
+		}
+		if obj.GetLabels()["app"] == "girus-lab-template" {
+			labTemplates++
+		}
+	}
+	if labTemplates == 0 {
+		t.Errorf("nenhum template de laboratório encontrado")
+	}
+}
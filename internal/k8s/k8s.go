@@ -1,26 +1,27 @@
 package k8s
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
-	"os"
+	"net/http"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/report"
+	"github.com/badtuxx/girus-cli/internal/templates"
 	"github.com/fatih/color"
-	"github.com/schollz/progressbar/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
 )
 
 var (
@@ -53,27 +54,35 @@ type ResourceConfig struct {
 	MemoryLimit   string
 }
 
-// NewKubernetesClient cria um novo cliente Kubernetes
+// NewKubernetesClient cria um novo cliente Kubernetes para o cluster Girus selecionado
 func NewKubernetesClient() (*KubernetesClient, error) {
-	// Path padrão do arquivo kubeconfig
-	var kubeconfig string
-	if home := homedir.HomeDir(); home != "" {
-		kubeconfig = filepath.Join(home, ".kube", "config")
-	}
+	return NewKubernetesClientForContext(common.KubeContext(), 0)
+}
 
-	// Cria a configuração a partir do arquivo kubeconfig
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao criar configuração: %w", err)
-	}
+// RestConfig cria a configuração de acesso a um contexto do kubeconfig selecionado
+// (o padrão respeita a variável KUBECONFIG) sem alterar o current-context
+func RestConfig(kubeContext string) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = common.Kubeconfig()
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+}
 
-	// Cria o clientset
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao criar o clientset: %w", err)
+// KubectlArgs retorna as flags do kubectl que selecionam o cluster Girus atual
+func KubectlArgs() []string {
+	var args []string
+	if kubeconfig := common.Kubeconfig(); kubeconfig != "" {
+		args = append(args, "--kubeconfig", kubeconfig)
+	}
+	if kubeContext := common.KubeContext(); kubeContext != "" {
+		args = append(args, "--context", kubeContext)
 	}
+	return args
+}
 
-	return &KubernetesClient{clientset: clientset}, nil
+// KubectlCommand cria um comando kubectl apontando para o cluster Girus selecionado
+func KubectlCommand(args ...string) *exec.Cmd {
+	return exec.Command("kubectl", append(KubectlArgs(), args...)...)
 }
 
 // IsPodRunning checa se um pod está em execução
@@ -195,7 +204,10 @@ func (k *KubernetesClient) StopDeployAndWait(ctx context.Context, namespace, pod
 
 // CreateDeployment cria um deployment do backend ou do frontend do girus
 func (k *KubernetesClient) CreateDeployment(ctx context.Context, namespace, name string) error {
-	image := fmt.Sprintf("linuxtips/%s:latest", name)
+	image, err := templates.ComponentImage(name)
+	if err != nil {
+		return err
+	}
 	labels := map[string]string{
 		"app": name,
 	}
@@ -348,7 +360,7 @@ func (k *KubernetesClient) CreateDeployment(ctx context.Context, namespace, name
 		},
 	}
 
-	_, err := k.clientset.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	_, err = k.clientset.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("falha ao criar o deploy %s no namespace %s: %w", name, namespace, err)
 	}
@@ -356,267 +368,118 @@ func (k *KubernetesClient) CreateDeployment(ctx context.Context, namespace, name
 	return nil
 }
 
-// waitForPodsReady espera até que os pods do Girus (backend e frontend) estejam prontos
-func WaitForPodsReady(namespace string, timeout time.Duration) error {
-	// Criar formatadores de cores
-	green := color.New(color.FgGreen).SprintFunc()
-	red := color.New(color.FgRed).SprintFunc()
-	magenta := color.New(color.FgMagenta).SprintFunc()
+// WaitForPodsReady espera até que os pods do Girus (backend e frontend)
+// estejam prontos e a API responda, reportando quantos componentes já estão
+// prontos e o estado de cada um
+func WaitForPodsReady(namespace string, timeout time.Duration, r report.Reporter) error {
+	step := common.T("Aguardando os pods do Girus", "Esperando los pods de Girus")
+	r.Start(step)
 
-	fmt.Println("\nAguardando os pods do Girus inicializarem...")
+	client, err := NewKubernetesClient()
+	if err != nil {
+		err = fmt.Errorf("falha ao criar cliente Kubernetes: %w", err)
+		r.Fail(step, err)
+		return err
+	}
 
-	start := time.Now()
-	bar := progressbar.NewOptions(100,
-		progressbar.OptionSetDescription("Inicializando Girus..."),
-		progressbar.OptionSetWidth(80),
-		progressbar.OptionShowBytes(false),
-		progressbar.OptionSetPredictTime(false),
-		progressbar.OptionThrottle(65*time.Millisecond),
-		progressbar.OptionSetRenderBlankState(true),
-		progressbar.OptionSpinnerType(14),
-		progressbar.OptionFullWidth(),
-	)
-
-	backendReady := false
-	frontendReady := false
-	backendMessage := ""
-	frontendMessage := ""
+	components := []struct {
+		name     string
+		selector string
+		ready    bool
+		message  string
+	}{
+		{name: "Backend", selector: "app=girus-backend", message: "Pod ainda não criado"},
+		{name: "Frontend", selector: "app=girus-frontend", message: "Pod ainda não criado"},
+	}
 
+	start := time.Now()
 	for {
-		if time.Since(start) > timeout {
-			bar.Finish()
-			fmt.Println("\nStatus atual dos componentes:")
-			if backendReady {
-				fmt.Printf("%s %s: Pronto\n", green("SUCESSO:"), magenta("Backend"))
-			} else {
-				fmt.Printf("%s %s: %s\n", red("ERRO:"), magenta("Backend"), backendMessage)
+		ready := 0
+		var states []string
+		for i := range components {
+			component := &components[i]
+			if !component.ready {
+				if podReady, msg, err := client.getPodStatus(namespace, component.selector); err == nil {
+					component.ready, component.message = podReady, msg
+				}
 			}
-			if frontendReady {
-				fmt.Printf("%s %s: Pronto\n", green("SUCESSO:"), magenta("Frontend"))
-			} else {
-				fmt.Printf("%s %s: %s\n", red("ERRO:"), magenta("Frontend"), frontendMessage)
+			if component.ready {
+				ready++
 			}
-			return fmt.Errorf("timeout ao esperar pelos pods do Girus (5 minutos)")
+			states = append(states, component.name+": "+component.message)
 		}
 
-		// Verificar o backend
-		if !backendReady {
-			br, msg, err := getPodStatus(namespace, "app=girus-backend")
-			if err == nil {
-				backendReady = br
-				backendMessage = msg
+		// Com os dois pods prontos, falta a API responder
+		if ready == len(components) {
+			if healthy, err := client.checkHealthEndpoint(); err == nil && healthy {
+				r.Done(step, common.T("backend, frontend e API prontos", "backend, frontend y API listos"))
+				return nil
 			}
+			states = []string{common.T("aguardando a API responder", "esperando que la API responda")}
 		}
+		r.Progress(step, strings.Join(states, ", "), ready, len(components))
 
-		// Verificar o frontend
-		if !frontendReady {
-			fr, msg, err := getPodStatus(namespace, "app=girus-frontend")
-			if err == nil {
-				frontendReady = fr
-				frontendMessage = msg
-			}
-		}
-
-		// Se ambos estiverem prontos, vamos verificar a conectividade
-		if backendReady && frontendReady {
-			// Verificar se conseguimos acessar a API
-			healthy, err := checkHealthEndpoint()
-			if err != nil || !healthy {
-				// Ainda não está respondendo, vamos continuar tentando
-				bar.Add(1)
-				time.Sleep(500 * time.Millisecond)
-				continue
-			}
-
-			bar.Finish()
-			fmt.Printf("\n%s %s: Pronto\n", green("SUCESSO:"), magenta("Backend"))
-			fmt.Printf("%s %s: Pronto\n", green("SUCESSO:"), magenta("Frontend"))
-			fmt.Printf("%s %s: Respondendo\n", green("SUCESSO:"), magenta("Aplicação"))
-			return nil
+		if time.Since(start) > timeout {
+			err := fmt.Errorf("timeout ao esperar pelos pods do Girus (%s): %s", timeout, strings.Join(states, ", "))
+			r.Fail(step, err)
+			return err
 		}
-
-		bar.Add(1)
 		time.Sleep(500 * time.Millisecond)
 	}
 }
 
-// getPodStatus verifica o status de um pod e retorna uma mensagem descritiva
-func getPodStatus(namespace, selector string) (bool, string, error) {
-	// Verificar se o pod existe
-	cmd := exec.Command("kubectl", "get", "pods", "-n", namespace, "-l", selector, "-o", "jsonpath={.items[0].metadata.name}")
-	var out bytes.Buffer
-	cmd.Stdout = &out
+// getPodStatus verifica o status do primeiro pod do seletor e retorna uma mensagem descritiva
+func (k *KubernetesClient) getPodStatus(namespace, selector string) (bool, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	err := cmd.Run()
+	pods, err := k.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
 	if err != nil {
 		return false, "Pod não encontrado", err
 	}
-
-	podName := strings.TrimSpace(out.String())
-	if podName == "" {
+	if len(pods.Items) == 0 {
 		return false, "Pod ainda não criado", nil
 	}
 
-	// Verificar a fase atual do pod
-	phaseCmd := exec.Command("kubectl", "get", "pod", podName, "-n", namespace, "-o", "jsonpath={.status.phase}")
-	var phaseOut bytes.Buffer
-	phaseCmd.Stdout = &phaseOut
-
-	err = phaseCmd.Run()
-	if err != nil {
-		return false, "Erro ao verificar status", err
-	}
+	ready, msg := podReadiness(&pods.Items[0])
+	return ready, msg, nil
+}
 
-	phase := strings.TrimSpace(phaseOut.String())
-	if phase != "Running" {
-		return false, fmt.Sprintf("Status: %s", phase), nil
+// podReadiness interpreta a fase e a condição Ready de um pod
+func podReadiness(pod *corev1.Pod) (bool, string) {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false, fmt.Sprintf("Status: %s", pod.Status.Phase)
 	}
 
 	// Verificar se todos os containers estão prontos
-	readyCmd := exec.Command("kubectl", "get", "pod", podName, "-n", namespace, "-o", "jsonpath={.status.conditions[?(@.type=='Ready')].status}")
-	var readyOut bytes.Buffer
-	readyCmd.Stdout = &readyOut
-
-	err = readyCmd.Run()
-	if err != nil {
-		return false, "Erro ao verificar prontidão", err
-	}
-
-	readyStatus := strings.TrimSpace(readyOut.String())
-	if readyStatus != "True" {
-		return false, "Containers inicializando", nil
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return true, "Pronto"
+		}
 	}
-
-	return true, "Pronto", nil
+	return false, "Containers inicializando"
 }
 
 // checkHealthEndpoint verifica se a aplicação está respondendo ao endpoint de saúde
-func checkHealthEndpoint() (bool, error) {
-	// Verificar o mapeamento de porta do serviço
-	cmd := exec.Command("kubectl", "get", "svc", "-n", "girus", "girus-backend", "-o", "jsonpath={.spec.ports[0].nodePort}")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-
-	err := cmd.Run()
-	if err != nil {
-		// Tentar verificar diretamente o endpoint interno se não encontrarmos o NodePort
-		healthCmd := exec.Command("kubectl", "exec", "-n", "girus", "deploy/girus-backend", "--", "wget", "-q", "-O-", "-T", "2", "http://localhost:8080/api/v1/health")
-		return healthCmd.Run() == nil, nil
-	}
-
-	nodePort := strings.TrimSpace(out.String())
-	if nodePort == "" {
-		// Porta não encontrada, tentar verificar o serviço internamente
-		healthCmd := exec.Command("kubectl", "exec", "-n", "girus", "deploy/girus-backend", "--", "wget", "-q", "-O-", "-T", "2", "http://localhost:8080/api/v1/health")
-		return healthCmd.Run() == nil, nil
-	}
-
-	// Tentar acessar via NodePort
-	healthCmd := exec.Command("curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", fmt.Sprintf("http://localhost:%s/api/v1/health", nodePort))
-	var healthOut bytes.Buffer
-	healthCmd.Stdout = &healthOut
-
-	err = healthCmd.Run()
-	if err != nil {
-		return false, err
-	}
-
-	statusCode := strings.TrimSpace(healthOut.String())
-	return statusCode == "200", nil
-}
-
-// setupPortForward configura port-forward para os serviços do Girus
-func SetupPortForward(namespace string) error {
-	// Criar formatador de cores
-	green := color.New(color.FgGreen).SprintFunc()
-	magenta := color.New(color.FgMagenta).SprintFunc()
-
-	// Matar todos os processos de port-forward relacionados ao Girus para começar limpo
-	fmt.Println("   Limpando port-forwards existentes...")
-	exec.Command("bash", "-c", "pkill -f 'kubectl.*port-forward.*girus' || true").Run()
-	time.Sleep(1 * time.Second)
-
-	// Port-forward do backend em background
-	fmt.Println("   Configurando port-forward para o backend (" + magenta("8080") + ")...")
-	backendCmd := fmt.Sprintf("kubectl port-forward -n %s svc/girus-backend 8080:8080 --address 0.0.0.0 > /dev/null 2>&1 &", namespace)
-	err := exec.Command("bash", "-c", backendCmd).Run()
-	if err != nil {
-		return fmt.Errorf("erro ao iniciar port-forward do backend: %v", err)
-	}
-
-	// Verificar conectividade do backend
-	fmt.Println("   Verificando conectividade do backend...")
-	backendOK := false
-	for i := 0; i < 5; i++ {
-		healthCmd := exec.Command("curl", "-s", "--max-time", "2", "http://localhost:8080/api/v1/health")
-		if healthCmd.Run() == nil {
-			backendOK = true
-			fmt.Printf("   %s %s conectado com sucesso!\n", green("SUCESSO:"), magenta("Backend"))
-			break
-		}
-		fmt.Println("   Tentativa", i+1, "falhou, aguardando...")
-		time.Sleep(1 * time.Second)
-	}
-
-	if !backendOK {
-		return fmt.Errorf("não foi possível conectar ao backend após várias tentativas")
-	}
-
-	// ------------------------------------------------------------------------
-	// Port-forward do frontend - ABORDAGEM MAIS SIMPLES E DIRETA
-	// ------------------------------------------------------------------------
-	fmt.Println("   Configurando port-forward para o frontend (" + magenta("8000") + ")...")
-
-	// Tentar encontrar o script auxiliar para port-forward
-	scriptPath := filepath.Join(os.Getenv("HOME"), ".girus", "port-forward.sh")
-	if _, err := os.Stat(scriptPath); err == nil {
-		// Usar o script auxiliar que executa kubectl em background
-		fmt.Println("   Iniciando port-forward via script auxiliar...")
-		frontendCmd := exec.Command(scriptPath, namespace, "frontend")
-		frontendCmd.Stdout = &bytes.Buffer{}
-		frontendCmd.Stderr = &bytes.Buffer{}
-		err = frontendCmd.Run()
-		if err != nil {
-			return fmt.Errorf("erro ao executar script de port-forward: %v", err)
-		}
-		// Tentar extrair o PID do port-forward
-		pidExtractCmd := exec.Command("pgrep", "-f", "kubectl.*port-forward.*frontend")
-		var pidOut bytes.Buffer
-		pidExtractCmd.Stdout = &pidOut
-		if pidExtractCmd.Run() == nil {
-			fmt.Printf("   Port-forward iniciado com PID: %s\n", strings.TrimSpace(pidOut.String()))
-		}
-	} else {
-		// Usar abordagem direta com kubectl
-		frontendCmd := fmt.Sprintf("kubectl port-forward -n %s svc/girus-frontend 8000:80 --address 0.0.0.0 > /dev/null 2>&1 &", namespace)
-		err = exec.Command("bash", "-c", frontendCmd).Run()
+func (k *KubernetesClient) checkHealthEndpoint() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Nos modos nodeport e ingress o backend é acessível diretamente pelo host
+	if mode := k.ExposeMode(ctx); mode != cluster.ExposePortForward {
+		httpClient := &http.Client{Timeout: 2 * time.Second}
+		resp, err := httpClient.Get(BackendURL(mode) + "/api/v1/health")
 		if err != nil {
-			return fmt.Errorf("erro ao iniciar port-forward do frontend: %v", err)
+			return false, err
 		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK, nil
 	}
 
-	// Verificar se o frontend está acessível
-	fmt.Println("   Verificando conectividade do frontend...")
-	frontendOK := false
-	for i := 0; i < 5; i++ {
-		frontendCheckCmd := exec.Command("curl", "-s", "--max-time", "2", "-o", "/dev/null", "-w", "%{http_code}", "http://localhost:8000")
-		var out bytes.Buffer
-		frontendCheckCmd.Stdout = &out
-		if frontendCheckCmd.Run() == nil {
-			statusCode := strings.TrimSpace(out.String())
-			if statusCode == "200" || statusCode == "301" || statusCode == "302" {
-				frontendOK = true
-				fmt.Printf("   %s %s conectado com sucesso!\n", green("SUCESSO:"), magenta("Frontend"))
-				break
-			}
-		}
-		fmt.Println("   Tentativa", i+1, "falhou, aguardando...")
-		time.Sleep(1 * time.Second)
-	}
-
-	if !frontendOK {
-		return fmt.Errorf("não foi possível conectar ao frontend após várias tentativas")
+	// Sem acesso pelo host, consultar o serviço pelo proxy do API server
+	_, err := k.clientset.CoreV1().Services("girus").ProxyGet("http", "girus-backend", "8080", "/api/v1/health", nil).DoRaw(ctx)
+	if err != nil {
+		return false, err
 	}
-
-	return nil
+	return true, nil
 }
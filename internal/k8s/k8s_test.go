@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPodReadiness(t *testing.T) {
+	tests := []struct {
+		name  string
+		pod   corev1.Pod
+		ready bool
+	}{
+		{"pendente", corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}}, false},
+		{"sem condição Ready", corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}, false},
+		{"Ready falso", corev1.Pod{Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		}}, false},
+		{"pronto", corev1.Pod{Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+		}}, true},
+	}
+	for _, tt := range tests {
+		if ready, msg := podReadiness(&tt.pod); ready != tt.ready {
+			t.Errorf("%s: podReadiness = %v (%s), esperado %v", tt.name, ready, msg, tt.ready)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+
+package k8s
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockPIDFile trava o arquivo de PID sem bloquear. O sistema libera a trava
+// quando o processo termina, mesmo sem remover o arquivo.
+func lockPIDFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
+
+// unlockPIDFile libera a trava obtida com lockPIDFile
+func unlockPIDFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package k8s
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// pidLockOffset posiciona a trava após o conteúdo do arquivo: no Windows a
+// trava é obrigatória e impediria a leitura do PID por outros processos
+const pidLockOffset = 1 << 30
+
+// lockPIDFile trava o arquivo de PID sem bloquear. O sistema libera a trava
+// quando o processo termina, mesmo sem remover o arquivo.
+func lockPIDFile(file *os.File) error {
+	overlapped := &windows.Overlapped{Offset: pidLockOffset}
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, overlapped)
+}
+
+// unlockPIDFile libera a trava obtida com lockPIDFile
+func unlockPIDFile(file *os.File) error {
+	overlapped := &windows.Overlapped{Offset: pidLockOffset}
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, overlapped)
+}
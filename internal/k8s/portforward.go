@@ -0,0 +1,583 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	// portForwardFile é o nome base, em ~/.girus, dos arquivos do 'girus
+	// port-forward' de cada cluster: port-forward-<contexto>.pid, com o PID do
+	// processo em execução, e port-forward-<contexto>.log, com a saída dele em
+	// segundo plano
+	portForwardFile = "port-forward"
+
+	// Intervalos entre as tentativas de reconexão de um encaminhamento
+	reconnectMinDelay = time.Second
+	reconnectMaxDelay = 10 * time.Second
+	// podCheckInterval é o intervalo de verificação do pod encaminhado
+	podCheckInterval = 2 * time.Second
+)
+
+// PortForward descreve o encaminhamento de uma porta local para a porta de um serviço
+type PortForward struct {
+	Service    string
+	LocalPort  int
+	RemotePort int
+}
+
+// String retorna o encaminhamento no formato localhost:porta -> svc/serviço:porta
+func (p PortForward) String() string {
+	return fmt.Sprintf("localhost:%d -> svc/%s:%d", p.LocalPort, p.Service, p.RemotePort)
+}
+
+// GirusPortForwards retorna os encaminhamentos do backend e do frontend do
+// Girus para as portas do host informadas
+func GirusPortForwards(ports common.HostPorts) []PortForward {
+	return []PortForward{
+		{Service: "girus-backend", LocalPort: ports.Backend, RemotePort: 8080},
+		{Service: "girus-frontend", LocalPort: ports.Frontend, RemotePort: 80},
+	}
+}
+
+// PortForwardEvent informa uma mudança de estado de um encaminhamento:
+// conectado a um pod (Err nil) ou conexão perdida (Err preenchido)
+type PortForwardEvent struct {
+	Forward PortForward
+	Pod     string
+	Err     error
+}
+
+// PortForwarder mantém encaminhamentos de portas para serviços de um
+// namespace, reconectando a um novo pod quando o atual é reiniciado
+type PortForwarder struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+	namespace string
+	address   string
+}
+
+// NewPortForwarder cria um PortForwarder para o contexto informado que
+// escuta no endereço local address (ex: 127.0.0.1 ou 0.0.0.0)
+func NewPortForwarder(kubeContext, namespace, address string) (*PortForwarder, error) {
+	config, err := RestConfig(kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar kubeconfig: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente Kubernetes: %w", err)
+	}
+	return &PortForwarder{config: config, clientset: clientset, namespace: namespace, address: address}, nil
+}
+
+// Run mantém os encaminhamentos até ctx ser cancelado. Cada serviço é
+// encaminhado de forma independente e reconectado quando seu pod deixa de
+// responder; somente portas locais ocupadas interrompem a execução.
+func (f *PortForwarder) Run(ctx context.Context, forwards []PortForward, onEvent func(PortForwardEvent)) error {
+	for _, forward := range forwards {
+		if err := checkLocalPort(f.address, forward.LocalPort); err != nil {
+			return err
+		}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, forward := range forwards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.keepForwarding(ctx, forward, func(event PortForwardEvent) {
+				if onEvent != nil {
+					mu.Lock()
+					defer mu.Unlock()
+					onEvent(event)
+				}
+			})
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// keepForwarding encaminha a porta e reconecta com espera crescente até ctx ser cancelado
+func (f *PortForwarder) keepForwarding(ctx context.Context, forward PortForward, onEvent func(PortForwardEvent)) {
+	delay := reconnectMinDelay
+	for ctx.Err() == nil {
+		pod, err := f.forwardOnce(ctx, forward, func(pod string) {
+			delay = reconnectMinDelay
+			onEvent(PortForwardEvent{Forward: forward, Pod: pod})
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("pod %s não está mais disponível", pod)
+		}
+		onEvent(PortForwardEvent{Forward: forward, Pod: pod, Err: err})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, reconnectMaxDelay)
+	}
+}
+
+// forwardOnce conecta a porta local a um pod pronto do serviço e retorna
+// quando a conexão é perdida, o pod deixa de estar pronto ou ctx é cancelado
+func (f *PortForwarder) forwardOnce(ctx context.Context, forward PortForward, onReady func(pod string)) (string, error) {
+	pod, targetPort, err := f.servicePod(ctx, forward.Service, forward.RemotePort)
+	if err != nil {
+		return "", err
+	}
+
+	dialer, err := f.dialer(pod.Name)
+	if err != nil {
+		return pod.Name, err
+	}
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	ports := []string{fmt.Sprintf("%d:%d", forward.LocalPort, targetPort)}
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{f.address}, ports, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return pod.Name, err
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- forwarder.ForwardPorts() }()
+
+	ticker := time.NewTicker(podCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-readyCh:
+			readyCh = nil
+			onReady(pod.Name)
+		case err := <-errCh:
+			return pod.Name, err
+		case <-ctx.Done():
+			close(stopCh)
+			<-errCh
+			return pod.Name, nil
+		case <-ticker.C:
+			// A conexão com um pod removido nem sempre é encerrada pelo servidor
+			if !f.podReady(ctx, pod.Name) {
+				close(stopCh)
+				<-errCh
+				return pod.Name, nil
+			}
+		}
+	}
+}
+
+// servicePod escolhe um pod pronto do serviço e resolve a targetPort
+// correspondente à porta remota do encaminhamento
+func (f *PortForwarder) servicePod(ctx context.Context, service string, remotePort int) (*corev1.Pod, int, error) {
+	svc, err := f.clientset.CoreV1().Services(f.namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao obter o serviço %s: %w", service, err)
+	}
+
+	pods, err := f.clientset.CoreV1().Pods(f.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao listar os pods do serviço %s: %w", service, err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !podServing(pod) {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			if int(port.Port) == remotePort {
+				return pod, targetPort(pod, port), nil
+			}
+		}
+		return nil, 0, fmt.Errorf("serviço %s não expõe a porta %d", service, remotePort)
+	}
+	return nil, 0, fmt.Errorf("nenhum pod pronto para o serviço %s", service)
+}
+
+// targetPort resolve a porta do container para a porta do serviço, inclusive
+// quando a targetPort é o nome de uma porta do container
+func targetPort(pod *corev1.Pod, port corev1.ServicePort) int {
+	if port.TargetPort.IntValue() != 0 {
+		return port.TargetPort.IntValue()
+	}
+	if name := port.TargetPort.String(); name != "" && name != "0" {
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == name {
+					return int(containerPort.ContainerPort)
+				}
+			}
+		}
+	}
+	return int(port.Port)
+}
+
+// podReady verifica se o pod ainda existe, não está sendo removido e está pronto
+func (f *PortForwarder) podReady(ctx context.Context, name string) bool {
+	pod, err := f.clientset.CoreV1().Pods(f.namespace).Get(ctx, name, metav1.GetOptions{})
+	return err == nil && podServing(pod)
+}
+
+// podServing indica se o pod está em execução, pronto e fora de remoção
+func podServing(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && isPodReady(*pod)
+}
+
+// dialer cria a conexão de port-forward com o pod via WebSocket, usando SPDY
+// quando o servidor não suporta o túnel (mesmo comportamento do kubectl)
+func (f *PortForwarder) dialer(pod string) (httpstream.Dialer, error) {
+	url := f.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(f.namespace).Name(pod).SubResource("portforward").URL()
+
+	transport, upgrader, err := spdy.RoundTripperFor(f.config)
+	if err != nil {
+		return nil, err
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	websocketDialer, err := portforward.NewSPDYOverWebsocketDialer(url, f.config)
+	if err != nil {
+		return nil, err
+	}
+	return portforward.NewFallbackDialer(websocketDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
+
+// checkLocalPort verifica se a porta local está livre antes de encaminhá-la
+func checkLocalPort(address string, port int) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("porta local %d em uso: %w", port, err)
+	}
+	return listener.Close()
+}
+
+// unsafeFileChars são os caracteres do contexto que não entram no nome dos
+// arquivos, como o / e o : dos contextos do EKS
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// portForwardFileName retorna o nome do arquivo do port-forward do contexto
+// com a extensão informada
+func portForwardFileName(kubeContext, ext string) string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(kubeContext, "_"), "._")
+	if name == "" {
+		return portForwardFile + ext
+	}
+	return portForwardFile + "-" + name + ext
+}
+
+// portForwardPath retorna o caminho, em ~/.girus, do arquivo do port-forward
+// do cluster selecionado com a extensão informada
+func portForwardPath(ext string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter o diretório home: %w", err)
+	}
+	return filepath.Join(home, ".girus", portForwardFileName(common.KubeContext(), ext)), nil
+}
+
+// PortForwardLogPath retorna o caminho do log do port-forward em segundo
+// plano do cluster selecionado
+func PortForwardLogPath() (string, error) {
+	return portForwardPath(".log")
+}
+
+// pidFile é o arquivo de PID aberto e travado pelo port-forward do processo
+// atual. A trava, e não apenas o PID, identifica o port-forward em execução:
+// um arquivo que sobrou de um reboot ou um PID reaproveitado por outro
+// processo não estão travados.
+var pidFile *os.File
+
+// WritePortForwardPID registra o processo atual como o port-forward do Girus
+// no cluster selecionado e mantém o arquivo travado até
+// RemovePortForwardPID ou o fim do processo. Falha se outro port-forward
+// registrado para o cluster ainda estiver em execução.
+func WritePortForwardPID() error {
+	if pid, running := RunningPortForward(); running && pid != os.Getpid() {
+		return fmt.Errorf("port-forward já em execução (PID %d)", pid)
+	}
+	path, err := portForwardPath(".pid")
+	if err != nil {
+		return err
+	}
+	if pidFile == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("erro ao criar o diretório %s: %w", filepath.Dir(path), err)
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+		if err != nil {
+			return fmt.Errorf("erro ao abrir %s: %w", path, err)
+		}
+		if err := lockPIDFile(file); err != nil {
+			file.Close()
+			return fmt.Errorf("port-forward já em execução (%s travado)", path)
+		}
+		pidFile = file
+	}
+	if err := pidFile.Truncate(0); err != nil {
+		return fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+	if _, err := pidFile.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+		return fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+	return nil
+}
+
+// RemovePortForwardPID libera e remove o arquivo de PID se ele pertence ao processo atual
+func RemovePortForwardPID() {
+	if pidFile == nil {
+		return
+	}
+	path := pidFile.Name()
+	unlockPIDFile(pidFile)
+	pidFile.Close()
+	pidFile = nil
+	os.Remove(path)
+}
+
+// RunningPortForward retorna o PID do port-forward registrado para o cluster
+// selecionado e se ele ainda está em execução, ou seja, se o arquivo de PID
+// continua travado pelo processo que o gravou
+func RunningPortForward() (int, bool) {
+	pid, err := readPortForwardPID()
+	if err != nil {
+		return 0, false
+	}
+	if pidFile != nil && pid == os.Getpid() {
+		return pid, true
+	}
+	return pid, pidFileLocked()
+}
+
+// StopPortForward encerra o port-forward registrado para o cluster selecionado
+// e remove o arquivo de PID. Os port-forwards de outros clusters não são
+// afetados, e um arquivo de PID sem trava é apenas removido, sem sinalizar o
+// processo que hoje usa aquele PID. Retorna o PID encerrado ou 0 quando
+// nenhum estava em execução.
+func StopPortForward() (int, error) {
+	pid, running := RunningPortForward()
+	path, err := portForwardPath(".pid")
+	if err != nil {
+		return 0, err
+	}
+	if !running {
+		os.Remove(path)
+		return 0, nil
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return 0, err
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		// No Windows só é possível encerrar o processo
+		if err := process.Kill(); err != nil {
+			return 0, fmt.Errorf("erro ao encerrar o port-forward (PID %d): %w", pid, err)
+		}
+	}
+	for range 20 {
+		if !pidFileLocked() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	os.Remove(path)
+	return pid, nil
+}
+
+// readPortForwardPID lê o PID registrado em ~/.girus/port-forward-<contexto>.pid
+func readPortForwardPID() (int, error) {
+	path, err := portForwardPath(".pid")
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// pidFileLocked verifica se o arquivo de PID do cluster selecionado está
+// travado por um port-forward em execução
+func pidFileLocked() bool {
+	path, err := portForwardPath(".pid")
+	if err != nil {
+		return false
+	}
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	if err := lockPIDFile(file); err != nil {
+		return true
+	}
+	unlockPIDFile(file)
+	return false
+}
+
+// StartPortForwardDaemon inicia 'girus port-forward' em segundo plano para o
+// cluster selecionado, escutando em address nas portas informadas, com a
+// saída no log em ~/.girus, e aguarda o registro do PID
+func StartPortForwardDaemon(address string, ports common.HostPorts) (int, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao localizar o executável do girus: %w", err)
+	}
+	logPath, err := PortForwardLogPath()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return 0, fmt.Errorf("erro ao criar o diretório %s: %w", filepath.Dir(logPath), err)
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao abrir %s: %w", logPath, err)
+	}
+	defer logFile.Close()
+
+	args := []string{
+		"port-forward", "--daemon", "--address", address, "--context", common.KubeContext(),
+		"--frontend-port", strconv.Itoa(ports.Frontend), "--backend-port", strconv.Itoa(ports.Backend),
+	}
+	if kubeconfig := common.Kubeconfig(); kubeconfig != "" {
+		args = append(args, "--kubeconfig", kubeconfig)
+	}
+	daemon := exec.Command(executable, args...)
+	daemon.Stdout = logFile
+	daemon.Stderr = logFile
+	if err := daemon.Start(); err != nil {
+		return 0, fmt.Errorf("erro ao iniciar o port-forward: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- daemon.Wait() }()
+
+	deadline := time.After(10 * time.Second)
+	for {
+		select {
+		case <-exited:
+			return 0, fmt.Errorf("o port-forward foi encerrado; veja %s", logPath)
+		case <-deadline:
+			// O processo ignora SIGHUP e SIGINT e manteria as portas ocupadas
+			daemon.Process.Kill()
+			<-exited
+			if path, err := portForwardPath(".pid"); err == nil && !pidFileLocked() {
+				os.Remove(path)
+			}
+			return 0, fmt.Errorf("timeout ao aguardar o port-forward; veja %s", logPath)
+		case <-time.After(100 * time.Millisecond):
+			if pid, running := RunningPortForward(); running && pid == daemon.Process.Pid {
+				return pid, nil
+			}
+		}
+	}
+}
+
+// SetupPortForward substitui o port-forward em execução por um novo em
+// segundo plano, escutando em todas as interfaces, e aguarda o backend e o
+// frontend responderem. Portas ocupadas são trocadas por portas livres, e as
+// escolhidas são gravadas para o cluster selecionado.
+func SetupPortForward() error {
+	if _, err := StopPortForward(); err != nil {
+		return err
+	}
+	ports, err := helpers.ChoosePorts(common.Ports())
+	if err != nil {
+		return err
+	}
+	pid, err := StartPortForwardDaemon("0.0.0.0", ports)
+	if err != nil {
+		return err
+	}
+	if err := common.SavePorts(ports); err != nil {
+		return err
+	}
+
+	backendURL := BackendURL(cluster.ExposePortForward) + "/api/v1/health"
+	if err := waitForURL(backendURL, 30*time.Second); err != nil {
+		return fmt.Errorf("não foi possível conectar ao backend (PID %d): %w", pid, err)
+	}
+	if err := waitForURL(AccessURL(cluster.ExposePortForward), 30*time.Second); err != nil {
+		return fmt.Errorf("não foi possível conectar ao frontend (PID %d): %w", pid, err)
+	}
+	return nil
+}
+
+// PortForwardNeeded indica se o port-forward precisa ser (re)iniciado: quando
+// o Girus é acessado por port-forward e nenhum está registrado ou o backend e
+// o frontend não respondem por ele
+func PortForwardNeeded() bool {
+	if client, err := NewKubernetesClient(); err == nil && client.ExposeMode(context.Background()) != cluster.ExposePortForward {
+		return false
+	}
+	if _, running := RunningPortForward(); !running {
+		return true
+	}
+	return !urlResponds(BackendURL(cluster.ExposePortForward)+"/api/v1/health") ||
+		!urlResponds(AccessURL(cluster.ExposePortForward))
+}
+
+// waitForURL aguarda a URL responder com sucesso até o timeout
+func waitForURL(url string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for !urlResponds(url) {
+		if time.Now().After(deadline) {
+			return errors.New("timeout ao aguardar " + url)
+		}
+		time.Sleep(time.Second)
+	}
+	return nil
+}
+
+// urlResponds verifica se a URL responde com um status de sucesso ou redirecionamento
+func urlResponds(url string) bool {
+	client := &http.Client{
+		Timeout: 2 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < http.StatusBadRequest
+}
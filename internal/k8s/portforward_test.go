@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPortForwardPID(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, running := RunningPortForward(); running {
+		t.Fatalf("nenhum port-forward deveria estar registrado")
+	}
+	if err := WritePortForwardPID(); err != nil {
+		t.Fatalf("WritePortForwardPID retornou erro: %v", err)
+	}
+	if pid, running := RunningPortForward(); !running || pid != os.Getpid() {
+		t.Errorf("PID registrado inesperado: %d (em execução: %v)", pid, running)
+	}
+
+	// O PID é registrado por cluster: o de outro contexto não é afetado
+	common.SetClusterName("outro")
+	if _, running := RunningPortForward(); running {
+		t.Errorf("o port-forward de outro contexto não deveria estar registrado")
+	}
+	if pid, err := StopPortForward(); err != nil || pid != 0 {
+		t.Errorf("StopPortForward de outro contexto = %d, %v; esperado 0, nil", pid, err)
+	}
+	common.SetClusterName(common.DefaultClusterName)
+
+	RemovePortForwardPID()
+	if _, running := RunningPortForward(); running {
+		t.Errorf("PID deveria ter sido removido")
+	}
+}
+
+func TestStalePortForwardPID(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Um arquivo sem trava, como o que sobra após um reboot, aponta para um
+	// processo vivo que não é um port-forward e não pode ser sinalizado
+	path, err := portForwardPath(".pid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, running := RunningPortForward(); running {
+		t.Errorf("um arquivo de PID sem trava não deveria indicar port-forward em execução")
+	}
+	if pid, err := StopPortForward(); err != nil || pid != 0 {
+		t.Errorf("StopPortForward = %d, %v; esperado 0, nil", pid, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("o arquivo de PID obsoleto deveria ter sido removido")
+	}
+	if err := WritePortForwardPID(); err != nil {
+		t.Fatalf("WritePortForwardPID retornou erro: %v", err)
+	}
+	RemovePortForwardPID()
+}
+
+func TestPortForwardFileName(t *testing.T) {
+	tests := map[string]string{
+		"kind-girus": "port-forward-kind-girus.pid",
+		"arn:aws:eks:us-east-1:123:cluster/girus": "port-forward-arn_aws_eks_us-east-1_123_cluster_girus.pid",
+		"": "port-forward.pid",
+	}
+	for kubeContext, want := range tests {
+		if got := portForwardFileName(kubeContext, ".pid"); got != want {
+			t.Errorf("portForwardFileName(%q) = %q, esperado %q", kubeContext, got, want)
+		}
+	}
+}
+
+func TestTargetPort(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8081}},
+	}}}}
+
+	tests := []struct {
+		port corev1.ServicePort
+		want int
+	}{
+		{corev1.ServicePort{Port: 8080, TargetPort: intstr.FromInt32(9090)}, 9090},
+		{corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")}, 8081},
+		{corev1.ServicePort{Port: 80}, 80},
+	}
+	for _, tt := range tests {
+		if got := targetPort(pod, tt.port); got != tt.want {
+			t.Errorf("targetPort(%v) = %d, esperado %d", tt.port.TargetPort, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IsManagedInstall indica se o Girus foi instalado com 'girus install',
+// caso em que os objetos aplicados devem manter ManagedByLabel
+func (k *KubernetesClient) IsManagedInstall(ctx context.Context) (bool, error) {
+	namespace, err := k.clientset.CoreV1().Namespaces().Get(ctx, "girus", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("o Girus não está implantado neste cluster")
+	}
+	if err != nil {
+		return false, fmt.Errorf("falha ao verificar o namespace girus: %w", err)
+	}
+	return namespace.Labels[ManagedByLabel] == ManagedByValue, nil
+}
+
+// UpgradeObjects filtra dos objetos embutidos os que o 'girus upgrade'
+// atualiza: os deployments da plataforma e os ConfigMaps de laboratório. Em
+// instalações feitas com 'girus install' (managed), os objetos mantêm o
+// ManagedByLabel usado pelo 'girus uninstall'.
+func UpgradeObjects(objects []*unstructured.Unstructured, managed bool) []*unstructured.Unstructured {
+	if managed {
+		MarkManaged(objects)
+	}
+	var selected []*unstructured.Unstructured
+	for _, obj := range objects {
+		if obj.GetNamespace() != "girus" {
+			continue
+		}
+		isDeployment := obj.GetKind() == "Deployment"
+		isLabTemplate := obj.GetKind() == "ConfigMap" && obj.GetLabels()["app"] == "girus-lab-template"
+		if isDeployment || isLabTemplate {
+			selected = append(selected, obj)
+		}
+	}
+	return selected
+}
+
+// WaitForRollout aguarda o rolling update do deployment, repassando a onLine
+// cada linha do 'kubectl rollout status' (réplicas atualizadas, pendentes etc.)
+func WaitForRollout(namespace, name string, timeout time.Duration, onLine func(string)) error {
+	cmd := KubectlCommand("rollout", "status", "deployment/"+name, "-n", namespace, "--timeout="+timeout.String())
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("falha ao executar kubectl rollout status: %w", err)
+	}
+
+	var last string
+	scanner := bufio.NewScanner(pipe)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		last = line
+		if onLine != nil {
+			onLine(line)
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("falha ao aguardar o rolling update de %s: %w: %s", name, err, last)
+	}
+	return nil
+}
+
+// WaitForHealth aguarda o backend responder em /api/v1/health
+func WaitForHealth(timeout time.Duration) error {
+	client, err := NewKubernetesClient()
+	if err != nil {
+		return fmt.Errorf("falha ao criar cliente Kubernetes: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if healthy, err := client.checkHealthEndpoint(); err == nil && healthy {
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+	return fmt.Errorf("o backend não respondeu em /api/v1/health após %s", timeout)
+}
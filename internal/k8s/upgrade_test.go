@@ -0,0 +1,21 @@
+package k8s
+
+import "testing"
+
+func TestUpgradeObjectsKeepsManagedLabel(t *testing.T) {
+	for _, managed := range []bool{true, false} {
+		embedded, err := EmbeddedObjects()
+		if err != nil {
+			t.Fatalf("EmbeddedObjects retornou erro: %v", err)
+		}
+		objects := UpgradeObjects(embedded, managed)
+		if len(objects) == 0 {
+			t.Fatalf("nenhum objeto selecionado para o upgrade")
+		}
+		for _, obj := range objects {
+			if got := obj.GetLabels()[ManagedByLabel] == ManagedByValue; got != managed {
+				t.Errorf("managed=%v: %s/%s com %s = %v", managed, obj.GetKind(), obj.GetName(), ManagedByLabel, got)
+			}
+		}
+	}
+}
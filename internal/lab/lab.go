@@ -1,8 +1,8 @@
 package lab
 
 import (
-	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,13 +10,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/badtuxx/girus-cli/internal/cluster"
+	"github.com/badtuxx/girus-cli/internal/common"
 	"github.com/badtuxx/girus-cli/internal/helpers"
 	"github.com/badtuxx/girus-cli/internal/k8s"
-	"github.com/schollz/progressbar/v3"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+	"github.com/badtuxx/girus-cli/internal/report"
 )
 
-// addLabFromFile adiciona um novo template de laboratório a partir de um arquivo
-func AddLabFromFile(labFile string, verboseMode bool) {
+// AddLabFromFile adiciona um novo template de laboratório a partir de um
+// arquivo, reportando a aplicação e o reinício do backend em r. O arquivo pode
+// ser um ConfigMap de template ou um lab.yaml puro, que é empacotado em um
+// ConfigMap com source nas anotações de proveniência.
+func AddLabFromFile(labFile, source string, r report.Reporter) {
 	// Verificar se o arquivo existe
 	if _, err := os.Stat(labFile); os.IsNotExist(err) {
 		fmt.Fprintf(os.Stderr, "❌ Erro: arquivo '%s' não encontrado\n", labFile)
@@ -26,7 +32,7 @@ func AddLabFromFile(labFile string, verboseMode bool) {
 	fmt.Println("🔍 Verificando ambiente Girus...")
 
 	// Verificar se há um cluster Girus ativo
-	checkCmd := exec.Command("kubectl", "get", "namespace", "girus", "--no-headers", "--ignore-not-found")
+	checkCmd := k8s.KubectlCommand("get", "namespace", "girus", "--no-headers", "--ignore-not-found")
 	checkOutput, err := checkCmd.Output()
 	if err != nil || !strings.Contains(string(checkOutput), "girus") {
 		fmt.Fprintf(os.Stderr, "❌ Nenhum cluster Girus ativo encontrado\n")
@@ -35,7 +41,7 @@ func AddLabFromFile(labFile string, verboseMode bool) {
 	}
 
 	// Verificar o pod do backend (silenciosamente, só mostra mensagem em caso de erro)
-	backendCmd := exec.Command("kubectl", "get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.phase}")
+	backendCmd := k8s.KubectlCommand("get", "pods", "-n", "girus", "-l", "app=girus-backend", "-o", "jsonpath={.items[0].status.phase}")
 	backendOutput, err := backendCmd.Output()
 	if err != nil || string(backendOutput) != "Running" {
 		fmt.Fprintf(os.Stderr, "❌ O backend do Girus não está em execução\n")
@@ -43,24 +49,35 @@ func AddLabFromFile(labFile string, verboseMode bool) {
 		os.Exit(1)
 	}
 
-	// Ler o arquivo para verificar se é um ConfigMap válido
+	// Ler o arquivo para verificar se é um laboratório válido
 	content, err := os.ReadFile(labFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ Erro ao ler o arquivo '%s': %v\n", labFile, err)
 		os.Exit(1)
 	}
 
-	// Verificação simples se o arquivo parece ser um ConfigMap válido
-	fileContent := string(content)
-	if !strings.Contains(fileContent, "kind: ConfigMap") ||
-		!strings.Contains(fileContent, "app: girus-lab-template") {
-		fmt.Fprintf(os.Stderr, "❌ O arquivo não é um manifesto de laboratório válido\n")
-		fmt.Println("   O arquivo deve ser um ConfigMap com a label 'app: girus-lab-template'")
+	// Validar o laboratório contra o schema e gerar o ConfigMap do lab.yaml puro
+	manifest, doc, err := labschema.Manifest(content, labschema.Provenance{
+		Source:      source,
+		GeneratedBy: "girus-cli " + common.ShortVersion(),
+	})
+	if err != nil {
+		var invalid *labschema.ValidationError
+		if !errors.As(err, &invalid) {
+			fmt.Fprintf(os.Stderr, "❌ Erro ao ler o arquivo '%s': %v\n", labFile, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "❌ O arquivo não é um manifesto de laboratório válido:\n")
+		for _, issue := range invalid.Issues {
+			fmt.Fprintf(os.Stderr, "   %s:%s\n", labFile, issue)
+		}
+		fmt.Println("   O arquivo deve ser um lab.yaml ou um ConfigMap com a label 'app: girus-lab-template' e o laboratório em data.lab.yaml")
+		fmt.Println("   Use 'girus lab validate' para conferir o arquivo antes de aplicá-lo.")
 		os.Exit(1)
 	}
 
 	// Verificar se está instalando o lab do Docker e se o Docker está disponível
-	if strings.Contains(fileContent, "docker-basics") {
+	if strings.Contains(doc.Lab.Name, "docker-basics") {
 		fmt.Println("🐳 Detectado laboratório de Docker, verificando dependências...")
 
 		// Verificar se o Docker está instalado
@@ -93,12 +110,8 @@ func AddLabFromFile(labFile string, verboseMode bool) {
 				fmt.Println("\n   📦 Visite: https://www.docker.com/products/docker-desktop")
 			}
 
-			fmt.Println("\n   Você deseja continuar com a instalação do template? [s/N]")
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.ToLower(strings.TrimSpace(response))
-
-			if response != "s" && response != "sim" && response != "y" && response != "yes" {
+			fmt.Println()
+			if !helpers.Confirm("   Você deseja continuar com a instalação do template?", false) {
 				fmt.Println("Instalação cancelada.")
 				os.Exit(0)
 			}
@@ -110,251 +123,61 @@ func AddLabFromFile(labFile string, verboseMode bool) {
 	}
 
 	fmt.Printf("📦 Processando laboratório: %s\n", labFile)
-
-	// Aplicar o ConfigMap no cluster usando kubectl apply
-	if verboseMode {
-		fmt.Println("   Aplicando ConfigMap no cluster...")
-	}
-
-	// Aplicar o ConfigMap no cluster
-	if verboseMode {
-		// Executar normalmente mostrando o output
-		applyCmd := exec.Command("kubectl", "apply", "-f", labFile)
-		applyCmd.Stdout = os.Stdout
-		applyCmd.Stderr = os.Stderr
-		if err := applyCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Erro ao aplicar o laboratório: %v\n", err)
-			os.Exit(1)
-		}
-	} else {
-		// Usar barra de progresso
-		bar := progressbar.NewOptions(100,
-			progressbar.OptionSetDescription("   Aplicando laboratório"),
-			progressbar.OptionSetWidth(80),
-			progressbar.OptionShowBytes(false),
-			progressbar.OptionSetPredictTime(false),
-			progressbar.OptionThrottle(65*time.Millisecond),
-			progressbar.OptionSetRenderBlankState(true),
-			progressbar.OptionSpinnerType(14),
-			progressbar.OptionFullWidth(),
-		)
-
-		// Executar comando sem mostrar saída
-		applyCmd := exec.Command("kubectl", "apply", "-f", labFile)
-		var stderr bytes.Buffer
-		applyCmd.Stderr = &stderr
-
-		// Iniciar o comando
-		err := applyCmd.Start()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Erro ao iniciar o comando: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Atualizar a barra de progresso enquanto o comando está em execução
-		done := make(chan struct{})
-		go func() {
-			for {
-				select {
-				case <-done:
-					return
-				default:
-					bar.Add(1)
-					time.Sleep(50 * time.Millisecond)
-				}
-			}
-		}()
-
-		// Aguardar o final do comando
-		err = applyCmd.Wait()
-		close(done)
-		bar.Finish()
-
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "\n❌ Erro ao aplicar o laboratório: %v\n", err)
-			if verboseMode {
-				fmt.Fprintf(os.Stderr, "   Detalhes: %s\n", stderr.String())
-			}
-			os.Exit(1)
-		}
-	}
-
-	// Extrair o ID do lab (name) do arquivo YAML para mostrar na mensagem
-	var labID string
-	// Procurar pela linha 'name:' dentro do bloco lab.yaml:
-	labNameCmd := exec.Command("sh", "-c", fmt.Sprintf("grep -A10 'lab.yaml:' %s | grep 'name:' | head -1", labFile))
-	labNameOutput, err := labNameCmd.Output()
-	if err == nil {
-		nameLine := strings.TrimSpace(string(labNameOutput))
-		parts := strings.SplitN(nameLine, "name:", 2)
-		if len(parts) >= 2 {
-			labID = strings.TrimSpace(parts[1])
-		}
+	if doc.Bare {
+		fmt.Printf("   lab.yaml empacotado no ConfigMap %s/%s\n", doc.Namespace, doc.Name)
 	}
 
-	// Extrair também o título para exibição
-	var labTitle string
-	labTitleCmd := exec.Command("sh", "-c", fmt.Sprintf("grep -A10 'lab.yaml:' %s | grep 'title:' | head -1", labFile))
-	labTitleOutput, err := labTitleCmd.Output()
-	if err == nil {
-		titleLine := strings.TrimSpace(string(labTitleOutput))
-		parts := strings.SplitN(titleLine, "title:", 2)
-		if len(parts) >= 2 {
-			labTitle = strings.TrimSpace(parts[1])
-			labTitle = strings.Trim(labTitle, "\"'")
-		}
+	// Aplicar o ConfigMap no cluster; o kubectl informa o resultado de cada objeto
+	applyStep := "Aplicando o laboratório no cluster"
+	r.Start(applyStep)
+	applyCmd := k8s.KubectlCommand("apply", "-f", "-")
+	applyCmd.Stdin = bytes.NewReader(manifest)
+	applyOutput, err := applyCmd.CombinedOutput()
+	applied := strings.TrimSpace(string(applyOutput))
+	if err != nil {
+		r.Fail(applyStep, fmt.Errorf("%v: %s", err, applied))
+		os.Exit(1)
 	}
-
-	fmt.Println("\n🔄 Reiniciando backend para carregar o template...")
+	r.Done(applyStep, strings.ReplaceAll(applied, "\n", ", "))
 
 	// O backend apenas carrega os templates na inicialização
-	if verboseMode {
-		// Mostrar o output da reinicialização
-		fmt.Println("   (O backend do Girus carrega os templates apenas na inicialização)")
-		restartCmd := exec.Command("kubectl", "rollout", "restart", "deployment/girus-backend", "-n", "girus")
-		restartCmd.Stdout = os.Stdout
-		restartCmd.Stderr = os.Stderr
-		if err := restartCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠️  Erro ao reiniciar o backend: %v\n", err)
-			fmt.Println("   O template foi aplicado, mas pode ser necessário reiniciar o backend manualmente:")
-			fmt.Println("   kubectl rollout restart deployment/girus-backend -n girus")
-		}
-
-		// Aguardar o reinício completar
-		fmt.Println("   Aguardando o reinício do backend completar...")
-		waitCmd := exec.Command("kubectl", "rollout", "status", "deployment/girus-backend", "-n", "girus", "--timeout=60s")
-		// Redirecionar saída para não exibir detalhes do rollout
-		var waitOutput bytes.Buffer
-		waitCmd.Stdout = &waitOutput
-		waitCmd.Stderr = &waitOutput
-
-		// Iniciar indicador de progresso simples
-		spinChars := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-		spinIdx := 0
-		done := make(chan struct{})
-		go func() {
-			for {
-				select {
-				case <-done:
-					return
-				default:
-					fmt.Printf("\r   %s Aguardando... ", spinChars[spinIdx])
-					spinIdx = (spinIdx + 1) % len(spinChars)
-					time.Sleep(100 * time.Millisecond)
-				}
-			}
-		}()
-
-		// Executar e aguardar
-		waitCmd.Run()
-		close(done)
-		fmt.Println("\r   ✅ Backend reiniciado com sucesso!            ")
+	restartStep := "Reiniciando o backend para carregar o template"
+	r.Start(restartStep)
+	restartOutput, err := k8s.KubectlCommand("rollout", "restart", "deployment/girus-backend", "-n", "girus").CombinedOutput()
+	if err != nil {
+		r.Fail(restartStep, fmt.Errorf("%v: %s", err, strings.TrimSpace(string(restartOutput))))
+		fmt.Println("   O template foi aplicado, mas pode ser necessário reiniciar o backend manualmente:")
+		fmt.Println("   kubectl rollout restart deployment/girus-backend -n girus")
 	} else {
-		// Usar barra de progresso
-		bar := progressbar.NewOptions(100,
-			progressbar.OptionSetDescription("   Reiniciando backend"),
-			progressbar.OptionSetWidth(80),
-			progressbar.OptionShowBytes(false),
-			progressbar.OptionSetPredictTime(false),
-			progressbar.OptionThrottle(65*time.Millisecond),
-			progressbar.OptionSetRenderBlankState(true),
-			progressbar.OptionSpinnerType(14),
-			progressbar.OptionFullWidth(),
-		)
-
-		// Reiniciar o deployment do backend
-		restartCmd := exec.Command("kubectl", "rollout", "restart", "deployment/girus-backend", "-n", "girus")
-		var stderr bytes.Buffer
-		restartCmd.Stderr = &stderr
-
-		err := restartCmd.Run()
+		err := k8s.WaitForRollout("girus", "girus-backend", 60*time.Second, func(line string) {
+			r.Progress(restartStep, line, 0, 0)
+		})
 		if err != nil {
-			bar.Finish()
-			fmt.Fprintf(os.Stderr, "\n⚠️  Erro ao reiniciar o backend: %v\n", err)
-			if verboseMode {
-				fmt.Fprintf(os.Stderr, "   Detalhes: %s\n", stderr.String())
-			}
-			fmt.Println("   O template foi aplicado, mas pode ser necessário reiniciar o backend manualmente:")
-			fmt.Println("   kubectl rollout restart deployment/girus-backend -n girus")
+			r.Fail(restartStep, err)
 		} else {
-			// Aguardar o reinício completar
-			waitCmd := exec.Command("kubectl", "rollout", "status", "deployment/girus-backend", "-n", "girus", "--timeout=60s")
-
-			// Redirecionar saída para não exibir detalhes do rollout
-			var waitOutput bytes.Buffer
-			waitCmd.Stdout = &waitOutput
-			waitCmd.Stderr = &waitOutput
-
-			// Iniciar o comando
-			err = waitCmd.Start()
-			if err != nil {
-				bar.Finish()
-				fmt.Fprintf(os.Stderr, "\n⚠️  Erro ao verificar status do reinício: %v\n", err)
-			} else {
-				// Atualizar a barra de progresso enquanto o comando está em execução
-				done := make(chan struct{})
-				go func() {
-					for {
-						select {
-						case <-done:
-							return
-						default:
-							bar.Add(1)
-							time.Sleep(100 * time.Millisecond)
-						}
-					}
-				}()
-
-				// Aguardar o final do comando
-				waitCmd.Wait()
-				close(done)
-				fmt.Println("\r   ✅ Backend reiniciado com sucesso!            ")
-			}
-			bar.Finish()
+			r.Done(restartStep, "")
+			// Aguardar a API do novo pod responder antes de verificar o acesso
+			report.Run(r, "Aguardando a API do backend", func() error {
+				return k8s.WaitForHealth(time.Minute)
+			})
 		}
 	}
 
-	// Aguardar mais alguns segundos para que o backend reinicie completamente
-	fmt.Println("   Aguardando inicialização completa...")
-	time.Sleep(3 * time.Second)
-
-	// Após reiniciar o backend, verificar se precisamos recriar o port-forward
-	portForwardStatus := helpers.CheckPortForwardNeeded()
-
-	// Se port-forward é necessário, configurá-lo corretamente
-	if portForwardStatus {
+	// Após reiniciar o backend, verificar se o port-forward ainda responde. O
+	// 'girus port-forward' reconecta sozinho ao novo pod; só é preciso
+	// iniciá-lo quando não está em execução ou deixou de responder.
+	if k8s.PortForwardNeeded() {
 		fmt.Println("\n🔌 Reconfigurando port-forwards após reinício do backend...")
 
-		// Usar a função setupPortForward para garantir que ambos os serviços estejam acessíveis
-		err := k8s.SetupPortForward("girus")
+		err := k8s.SetupPortForward()
 		if err != nil {
 			fmt.Println("⚠️ Aviso:", err)
 			fmt.Println("   Para configurar manualmente, execute:")
-			fmt.Println("   kubectl port-forward -n girus svc/girus-backend 8080:8080 --address 0.0.0.0")
-			fmt.Println("   kubectl port-forward -n girus svc/girus-frontend 8000:80 --address 0.0.0.0")
+			fmt.Println("   girus port-forward --background")
 		} else {
 			fmt.Println("✅ Port-forwards configurados com sucesso!")
-			fmt.Println("   🔹 Backend: http://localhost:8080")
-			fmt.Println("   🔹 Frontend: http://localhost:8000")
-		}
-	} else {
-		// Verificar conexão com o frontend mesmo que o port-forward não seja necessário
-		checkCmd := exec.Command("curl", "-s", "--max-time", "1", "-o", "/dev/null", "-w", "%{http_code}", "http://localhost:8000")
-		var out bytes.Buffer
-		checkCmd.Stdout = &out
-
-		if checkCmd.Run() != nil || !strings.Contains(strings.TrimSpace(out.String()), "200") {
-			fmt.Println("\n⚠️ Detectado problema na conexão com o frontend.")
-			fmt.Println("   Reconfigurando port-forwards para garantir acesso...")
-
-			// Forçar reconfiguração de port-forwards
-			err := k8s.SetupPortForward("girus")
-			if err != nil {
-				fmt.Println("   ⚠️", err)
-				fmt.Println("   Configure manualmente: kubectl port-forward -n girus svc/girus-frontend 8000:80 --address 0.0.0.0")
-			} else {
-				fmt.Println("   ✅ Port-forwards reconfigurados com sucesso!")
-			}
+			fmt.Println("   🔹 Backend: " + k8s.BackendURL(cluster.ExposePortForward))
+			fmt.Println("   🔹 Frontend: " + k8s.AccessURL(cluster.ExposePortForward))
 		}
 	}
 
@@ -364,27 +187,22 @@ func AddLabFromFile(labFile string, verboseMode bool) {
 	// Exibir informações sobre o laboratório adicionado
 	fmt.Println("✅ LABORATÓRIO ADICIONADO COM SUCESSO!")
 
-	if labTitle != "" && labID != "" {
-		fmt.Printf("\n📚 Título: %s\n", labTitle)
-		fmt.Printf("🏷️  ID: %s\n", labID)
-	} else if labID != "" {
-		fmt.Printf("\n🏷️  ID do Laboratório: %s\n", labID)
-	}
+	fmt.Printf("\n📚 Título: %s\n", doc.Lab.Title)
+	fmt.Printf("🏷️  ID: %s\n", doc.Lab.Name)
 
 	fmt.Println("\n📋 PRÓXIMOS PASSOS:")
 	fmt.Println("  • Acesse o Girus no navegador para usar o novo laboratório:")
-	fmt.Println("    http://localhost:8000")
+	fmt.Println("    " + k8s.ClusterAccessURL())
 
 	fmt.Println("\n  • Para ver todos os laboratórios disponíveis via CLI:")
 	fmt.Println("    girus list labs")
 
 	fmt.Println("\n  • Para verificar detalhes do template adicionado:")
-	if labID != "" {
-		fmt.Printf("    kubectl describe configmap -n girus | grep -A20 %s\n", labID)
-	} else {
-		fmt.Println("    kubectl get configmaps -n girus -l app=girus-lab-template")
-		fmt.Println("    kubectl describe configmap <nome-do-configmap> -n girus")
+	namespace := doc.Namespace
+	if namespace == "" {
+		namespace = "girus"
 	}
+	fmt.Printf("    kubectl describe configmap %s -n %s\n", doc.Name, namespace)
 
 	// Linha final
 	fmt.Println(strings.Repeat("─", 60))
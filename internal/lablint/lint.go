@@ -0,0 +1,260 @@
+// Package lablint aplica aos templates de laboratório regras de boas práticas
+// de autoria que vão além do schema, como tarefas sem validação ou comandos
+// fora de crases
+package lablint
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/labschema"
+)
+
+// Severity é o nível de uma regra
+type Severity string
+
+// Níveis aceitos pelas regras. SeverityOff desativa a regra.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off"
+)
+
+// Severities são os níveis aceitos na configuração das regras
+var Severities = []Severity{SeverityError, SeverityWarning, SeverityInfo, SeverityOff}
+
+// Rule é uma regra do linter
+type Rule struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Severity    Severity `json:"severity"`
+	// Fixable informa se a regra tem correção automática (--fix)
+	Fixable bool `json:"fixable"`
+	check   func(doc *labschema.Document) []Finding
+}
+
+// Finding é um problema apontado por uma regra
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+	Fixable  bool     `json:"fixable"`
+	fix      *fix
+}
+
+// fix substitui o valor do escalar que começa em line e column
+type fix struct {
+	line, column int
+	// original é o valor atual, conferido nos escalares sem aspas
+	original, value string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%d:%d: %s [%s]", f.Line, f.Column, f.Message, f.Rule)
+}
+
+// Config altera o nível das regras, pelo ID
+type Config map[string]Severity
+
+// ParseConfig lê entradas no formato id=nível, como as da flag --rule e do
+// bloco lint.rules do arquivo de configuração
+func ParseConfig(entries map[string]string) (Config, error) {
+	config := Config{}
+	for id, level := range entries {
+		if Find(id) == nil {
+			return nil, fmt.Errorf("regra desconhecida %q", id)
+		}
+		severity := Severity(strings.ToLower(strings.TrimSpace(level)))
+		if !slices.Contains(Severities, severity) {
+			return nil, fmt.Errorf("nível inválido %q para a regra %s: use error, warning, info ou off", level, id)
+		}
+		config[id] = severity
+	}
+	return config, nil
+}
+
+// severity retorna o nível configurado da regra
+func (c Config) severity(rule *Rule) Severity {
+	if severity, ok := c[rule.ID]; ok {
+		return severity
+	}
+	return rule.Severity
+}
+
+// Find retorna a regra pelo ID, ou nil
+func Find(id string) *Rule {
+	for i := range Rules {
+		if Rules[i].ID == id {
+			return &Rules[i]
+		}
+	}
+	return nil
+}
+
+// Lint valida o arquivo contra o schema e aplica as regras, retornando os
+// problemas ordenados pela posição. Os problemas do schema aparecem sob a
+// regra schema.
+func Lint(data []byte, config Config) []Finding {
+	doc, issues := labschema.Validate(data)
+
+	var findings []Finding
+	for _, issue := range issues {
+		// Os tipos de dica são tratados pela regra própria, que sabe corrigi-los
+		if issue.Code == labschema.CodeTipType {
+			continue
+		}
+		findings = append(findings, Finding{Rule: RuleSchema, Line: issue.Line, Column: issue.Column, Path: issue.Path, Message: issue.Message})
+	}
+	if doc != nil && doc.Node != nil {
+		for _, rule := range Rules {
+			if rule.check == nil {
+				continue
+			}
+			for _, finding := range rule.check(doc) {
+				finding.Rule = rule.ID
+				// Sem as posições exatas, a correção poderia editar o trecho errado
+				if !doc.ExactPositions {
+					finding.fix = nil
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	result := findings[:0]
+	for _, finding := range findings {
+		finding.Severity = config.severity(Find(finding.Rule))
+		if finding.Severity == SeverityOff {
+			continue
+		}
+		finding.Fixable = finding.fix != nil
+		result = append(result, finding)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+		return result[i].Column < result[j].Column
+	})
+	return result
+}
+
+// Fix aplica as correções automáticas dos problemas e retorna o novo
+// conteúdo do arquivo, junto do número de correções aplicadas
+func Fix(data []byte, findings []Finding) ([]byte, int, error) {
+	lines := strings.Split(string(data), "\n")
+	var fixes []*fix
+	for _, finding := range findings {
+		if finding.fix != nil {
+			fixes = append(fixes, finding.fix)
+		}
+	}
+	// Da direita para a esquerda, para não deslocar as colunas das demais
+	sort.Slice(fixes, func(i, j int) bool {
+		if fixes[i].line != fixes[j].line {
+			return fixes[i].line > fixes[j].line
+		}
+		return fixes[i].column > fixes[j].column
+	})
+
+	applied := 0
+	for _, f := range fixes {
+		if f.line < 1 || f.line > len(lines) {
+			continue
+		}
+		line := []rune(lines[f.line-1])
+		start := f.column - 1
+		end := scalarEnd(line, start)
+		if end < 0 || line[start] != '"' && line[start] != '\'' && string(line[start:end]) != f.original {
+			continue
+		}
+		replacement := []rune(quote(f.value, line[start]))
+		lines[f.line-1] = string(slices.Concat(line[:start], replacement, line[end:]))
+		applied++
+	}
+
+	fixed := []byte(strings.Join(lines, "\n"))
+	// As correções não podem quebrar a sintaxe do arquivo
+	_, issues := labschema.Validate(fixed)
+	for _, issue := range issues {
+		if issue.Code == labschema.CodeSyntax {
+			return nil, 0, fmt.Errorf("as correções gerariam um YAML inválido: %s", issue)
+		}
+	}
+	return fixed, applied, nil
+}
+
+// scalarEnd retorna a posição logo após o escalar de uma linha que começa em
+// start, ou -1 quando o escalar continua nas linhas seguintes
+func scalarEnd(line []rune, start int) int {
+	if start < 0 || start >= len(line) {
+		return -1
+	}
+	switch line[start] {
+	case '"':
+		for i := start + 1; i < len(line); i++ {
+			switch line[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+		return -1
+	case '\'':
+		for i := start + 1; i < len(line); i++ {
+			if line[i] != '\'' {
+				continue
+			}
+			if i+1 < len(line) && line[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+		return -1
+	}
+	end := len(line)
+	for i := start + 1; i < len(line); i++ {
+		if line[i] == '#' && line[i-1] == ' ' {
+			end = i
+			break
+		}
+	}
+	for end > start && line[end-1] == ' ' {
+		end--
+	}
+	return end
+}
+
+// quote escreve o valor no estilo do escalar original: entre aspas simples,
+// entre aspas duplas ou sem aspas quando o valor é uma palavra simples
+func quote(value string, style rune) string {
+	switch {
+	case style == '\'':
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	case style != '"' && isWord(value):
+		return value
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// isWord informa se o valor é formado apenas por letras minúsculas
+func isWord(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package lablint
+
+import (
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/templates"
+)
+
+// TestEmbeddedTemplatesBaseline garante que os templates embutidos continuam
+// sem nenhum problema com as regras no nível padrão
+func TestEmbeddedTemplatesBaseline(t *testing.T) {
+	entries, err := fs.ReadDir(templates.ManifestFS, "manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "lab_") {
+			continue
+		}
+		name := path.Join("manifests", entry.Name())
+		data, err := fs.ReadFile(templates.ManifestFS, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, finding := range Lint(data, nil) {
+			t.Errorf("%s:%s", name, finding)
+		}
+	}
+}
+
+const sampleLab = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: exemplo-lab
+  labels:
+    app: girus-lab-template
+data:
+  lab.yaml: |
+    name: exemplo
+    title: "Exemplo"
+    description: "Laboratório de exemplo"
+    duration: 10m
+    image: "ubuntu:22.04"
+    tasks:
+      - name: "Listando arquivos"
+        description: "Descrição"
+        steps:
+          - "Liste os arquivos do diretório:"
+          - "ls -la /tmp"
+          - "` + "```" + `"
+          - "cat arquivo.txt"
+          - "` + "```" + `"
+        tips:
+          - type: aviso
+            title: "Dica"
+            content: "Conteúdo"
+        validation:
+          - command: "test -d /tmp; echo 'ok'"
+            expectedOutput: "ok"
+      - name: "Sem verificação"
+        description: "Descrição"
+        steps:
+          - "Apenas leia."
+`
+
+func TestLint(t *testing.T) {
+	findings := Lint([]byte(sampleLab), Config{RuleTaskWithoutValidation: SeverityError})
+
+	want := []string{
+		"19:13: o comando deve estar entre crases: `ls -la /tmp` [step-command-without-backticks]",
+		`24:19: tipo de dica desconhecido "aviso": use info, tip, warning [unknown-tip-type]`,
+		`28:22: o comando de validação sempre passa: o último comando, "echo 'ok'", imprime sempre a mesma saída [validation-always-passes]`,
+		`30:9: a tarefa "Sem verificação" não tem validation: o aluno a conclui sem nenhuma verificação [task-without-validation]`,
+	}
+	var got []string
+	for _, finding := range findings {
+		got = append(got, finding.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("problemas inesperados:\n%s\nesperado:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if findings[3].Severity != SeverityError {
+		t.Errorf("o nível configurado deveria substituir o padrão: %s", findings[3].Severity)
+	}
+}
+
+func TestFix(t *testing.T) {
+	findings := Lint([]byte(sampleLab), Config{})
+	fixed, applied, err := Fix([]byte(sampleLab), findings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != 2 {
+		t.Errorf("esperadas 2 correções, aplicadas %d", applied)
+	}
+	for _, line := range []string{"          - \"`ls -la /tmp`\"\n", "          - type: warning\n"} {
+		if !strings.Contains(string(fixed), line) {
+			t.Errorf("a correção deveria gerar a linha %q:\n%s", line, fixed)
+		}
+	}
+	for _, finding := range Lint(fixed, Config{}) {
+		if finding.Fixable {
+			t.Errorf("problema corrigível restante: %s", finding)
+		}
+	}
+}
+
+func TestAlwaysPasses(t *testing.T) {
+	cases := map[string]bool{
+		"true":                                   true,
+		"kubectl get pods || true":               true,
+		"command -v htop &>/dev/null; echo 'ok'": true,
+		"test -f arquivo.txt && echo 'ok' || echo 'erro'":                        false,
+		"echo $(cat arquivo.txt)":                                                false,
+		"grep -q 'a;b' arquivo.txt && echo 'ok'":                                 false,
+		"NODEPORT=$(kubectl get svc -o name; true); curl -s localhost:$NODEPORT": false,
+	}
+	for command, want := range cases {
+		if got := alwaysPasses(command) != ""; got != want {
+			t.Errorf("alwaysPasses(%q) = %v, esperado %v", command, got, want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package lablint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/badtuxx/girus-cli/internal/labschema"
+	"gopkg.in/yaml.v3"
+)
+
+// IDs das regras
+const (
+	RuleSchema                = "schema"
+	RuleTaskWithoutValidation = "task-without-validation"
+	RuleValidationAlwaysPass  = "validation-always-passes"
+	RuleStepCommandBackticks  = "step-command-without-backticks"
+	RuleUnknownTipType        = "unknown-tip-type"
+)
+
+// Rules são as regras do linter, com o nível padrão de cada uma
+var Rules = []Rule{
+	{
+		ID:          RuleSchema,
+		Description: "O arquivo deve seguir o schema dos laboratórios (o mesmo de 'girus lab validate')",
+		Severity:    SeverityError,
+	},
+	{
+		ID:          RuleTaskWithoutValidation,
+		Description: "Toda tarefa deve ter ao menos uma verificação em validation",
+		Severity:    SeverityWarning,
+		check:       checkTaskWithoutValidation,
+	},
+	{
+		ID:          RuleValidationAlwaysPass,
+		Description: "O comando de validação não pode terminar sempre com sucesso nem imprimir sempre a mesma saída",
+		Severity:    SeverityError,
+		check:       checkValidationAlwaysPasses,
+	},
+	{
+		ID:          RuleStepCommandBackticks,
+		Description: "Comandos nos passos devem estar entre crases, para o frontend exibi-los como código",
+		Severity:    SeverityWarning,
+		Fixable:     true,
+		check:       checkStepCommandBackticks,
+	},
+	{
+		ID:          RuleUnknownTipType,
+		Description: "O tipo da dica deve ser info, tip ou warning",
+		Severity:    SeverityError,
+		Fixable:     true,
+		check:       checkUnknownTipType,
+	},
+}
+
+// commandWords são os programas que indicam que um passo é um comando
+var commandWords = []string{
+	"apt", "apt-get", "awk", "aws", "cat", "cd", "chmod", "chown", "cp", "crontab",
+	"curl", "df", "docker", "docker-compose", "du", "echo", "export", "find", "free",
+	"git", "grep", "head", "helm", "htop", "id", "ip", "journalctl", "kill", "kubectl",
+	"ls", "mkdir", "mv", "ping", "ps", "rm", "sed", "ss", "sudo", "systemctl", "tail",
+	"tar", "terraform", "top", "touch", "useradd", "usermod", "wget",
+}
+
+// tipAliases são os tipos de dica inválidos que têm um equivalente claro
+var tipAliases = map[string]string{
+	"aviso":      "warning",
+	"atenção":    "warning",
+	"atencao":    "warning",
+	"caution":    "warning",
+	"danger":     "warning",
+	"warn":       "warning",
+	"dica":       "tip",
+	"hint":       "tip",
+	"consejo":    "tip",
+	"note":       "info",
+	"nota":       "info",
+	"informacao": "info",
+	"informação": "info",
+}
+
+// tasks percorre as tarefas do documento, com o caminho de cada uma
+func tasks(doc *labschema.Document, visit func(task *yaml.Node, path string)) {
+	list := field(doc.Node, "tasks")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, task := range list.Content {
+		if task.Kind == yaml.MappingNode {
+			visit(task, fmt.Sprintf("tasks[%d]", i))
+		}
+	}
+}
+
+// items percorre os itens de uma lista da tarefa, com o caminho de cada um
+func items(task *yaml.Node, path, key string, visit func(item *yaml.Node, path string)) {
+	list := field(task, key)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, item := range list.Content {
+		visit(item, fmt.Sprintf("%s.%s[%d]", path, key, i))
+	}
+}
+
+// field retorna o valor da chave em um mapeamento, ou nil
+func field(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// at cria um problema na posição do nó
+func at(node *yaml.Node, path, format string, args ...any) Finding {
+	return Finding{Line: node.Line, Column: node.Column, Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+func checkTaskWithoutValidation(doc *labschema.Document) []Finding {
+	var findings []Finding
+	tasks(doc, func(task *yaml.Node, path string) {
+		if field(task, "validation") == nil {
+			name := ""
+			if n := field(task, "name"); n != nil {
+				name = n.Value
+			}
+			findings = append(findings, at(task, path, "a tarefa %q não tem validation: o aluno a conclui sem nenhuma verificação", name))
+		}
+	})
+	return findings
+}
+
+func checkValidationAlwaysPasses(doc *labschema.Document) []Finding {
+	var findings []Finding
+	tasks(doc, func(task *yaml.Node, path string) {
+		items(task, path, "validation", func(item *yaml.Node, path string) {
+			command := field(item, "command")
+			if command == nil || command.Kind != yaml.ScalarNode {
+				return
+			}
+			if reason := alwaysPasses(command.Value); reason != "" {
+				findings = append(findings, at(command, path+".command", "o comando de validação sempre passa: %s", reason))
+			}
+		})
+	})
+	return findings
+}
+
+func checkStepCommandBackticks(doc *labschema.Document) []Finding {
+	var findings []Finding
+	tasks(doc, func(task *yaml.Node, path string) {
+		// Passos entre cercas ``` são o conteúdo de um bloco de código
+		fenced := false
+		items(task, path, "steps", func(step *yaml.Node, path string) {
+			if step.Kind != yaml.ScalarNode {
+				return
+			}
+			text := step.Value
+			if trimmed := strings.TrimSpace(text); strings.HasPrefix(trimmed, "```") {
+				// Um passo com a abertura e o fechamento do bloco não muda o estado
+				if strings.Count(trimmed, "```") == 1 {
+					fenced = !fenced
+				}
+				return
+			}
+			if fenced || !looksLikeCommand(text) {
+				return
+			}
+			finding := at(step, path, "o comando deve estar entre crases: `%s`", text)
+			if step.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				finding.fix = &fix{line: step.Line, column: step.Column, original: text, value: "`" + text + "`"}
+			}
+			findings = append(findings, finding)
+		})
+	})
+	return findings
+}
+
+func checkUnknownTipType(doc *labschema.Document) []Finding {
+	var findings []Finding
+	tasks(doc, func(task *yaml.Node, path string) {
+		items(task, path, "tips", func(tip *yaml.Node, path string) {
+			typ := field(tip, "type")
+			if typ == nil || typ.Kind != yaml.ScalarNode || slices.Contains(labschema.TipTypes, typ.Value) {
+				return
+			}
+			finding := at(typ, path+".type", "tipo de dica desconhecido %q: use %s", typ.Value, strings.Join(labschema.TipTypes, ", "))
+			value := strings.ToLower(strings.TrimSpace(typ.Value))
+			if alias, ok := tipAliases[value]; ok {
+				value = alias
+			}
+			if slices.Contains(labschema.TipTypes, value) {
+				finding.fix = &fix{line: typ.Line, column: typ.Column, original: typ.Value, value: value}
+			}
+			findings = append(findings, finding)
+		})
+	})
+	return findings
+}
+
+// looksLikeCommand informa se um passo em texto é apenas um comando, sem
+// crases: uma única linha, sem indentação, que começa com um programa
+// conhecido e não termina como uma frase introdutória
+func looksLikeCommand(text string) bool {
+	if text == "" || strings.ContainsAny(text, "`\n") || text != strings.TrimSpace(text) {
+		return false
+	}
+	if strings.HasSuffix(text, ":") {
+		return false
+	}
+	word := strings.Fields(text)[0]
+	return slices.Contains(commandWords, word) || strings.HasPrefix(word, "./")
+}
+
+// alwaysPasses retorna o motivo pelo qual o comando de validação sempre
+// termina com sucesso e a mesma saída, ou vazio quando o resultado depende
+// do ambiente
+func alwaysPasses(command string) string {
+	segments := split(command, ";\n")
+	last := ""
+	for i := len(segments) - 1; i >= 0; i-- {
+		if last = strings.TrimSpace(segments[i]); last != "" {
+			break
+		}
+	}
+	// O comando vazio já é apontado pelo schema
+	if last == "" {
+		return ""
+	}
+
+	switch last {
+	case "true", ":", "exit 0":
+		return fmt.Sprintf("o último comando é %q", last)
+	}
+
+	// Um "|| true" no fim mascara a falha de todo o comando
+	alternatives := split(last, "|")
+	if len(alternatives) > 1 {
+		fallback := strings.TrimSpace(alternatives[len(alternatives)-1])
+		if fallback == "true" || fallback == ":" || fallback == "exit 0" {
+			return fmt.Sprintf("o %q no fim mascara a falha do comando", "|| "+fallback)
+		}
+	}
+
+	// Um echo constante como último comando imprime sempre a mesma saída
+	fields := strings.Fields(last)
+	if (fields[0] == "echo" || fields[0] == "printf") && len(split(last, "|&")) == 1 && !strings.ContainsAny(last, "$`") {
+		return fmt.Sprintf("o último comando, %q, imprime sempre a mesma saída", last)
+	}
+	return ""
+}
+
+// split divide o comando do shell nos separadores que estão fora de aspas,
+// crases e subshells $(...). Separadores repetidos, como || e &&, contam
+// como um só.
+func split(command, separators string) []string {
+	var segments []string
+	var quote rune
+	depth, start := 0, 0
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == '\\' && quote == '"' {
+				i++
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '\\':
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			depth++
+			i++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0 && strings.ContainsRune(separators, r):
+			segments = append(segments, string(runes[start:i]))
+			for i+1 < len(runes) && runes[i+1] == r {
+				i++
+			}
+			start = i + 1
+		}
+	}
+	return append(segments, string(runes[start:]))
+}
@@ -0,0 +1,120 @@
+package lablint
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+// Result são os problemas encontrados em um arquivo
+type Result struct {
+	File     string    `json:"file"`
+	Findings []Finding `json:"findings"`
+}
+
+// Estrutura mínima do SARIF 2.1.0, o formato aceito pelo code scanning do
+// GitHub e por outras ferramentas de CI
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine   int `json:"startLine"`
+			StartColumn int `json:"startColumn"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// sarifLevel converte o nível da regra no nível do SARIF
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityOff:
+		return "none"
+	}
+	return "note"
+}
+
+// WriteSARIF escreve os resultados no formato SARIF 2.1.0
+func WriteSARIF(w io.Writer, version string, results []Result, config Config) error {
+	driver := sarifDriver{
+		Name:           "girus lab lint",
+		Version:        version,
+		InformationURI: "https://github.com/badtuxx/girus-cli",
+	}
+	for i := range Rules {
+		rule := sarifRule{ID: Rules[i].ID, ShortDescription: sarifMessage{Text: Rules[i].Description}}
+		rule.DefaultConfiguration.Level = sarifLevel(config.severity(&Rules[i]))
+		driver.Rules = append(driver.Rules, rule)
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			location := sarifLocation{}
+			location.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(result.File)
+			location.PhysicalLocation.Region.StartLine = max(finding.Line, 1)
+			location.PhysicalLocation.Region.StartColumn = max(finding.Column, 1)
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    finding.Rule,
+				Level:     sarifLevel(finding.Severity),
+				Message:   sarifMessage{Text: finding.Message},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,248 @@
+// Package labscaffold gera o esqueleto de um novo laboratório: os ConfigMaps
+// em português e em espanhol, no layout labs/<id>/lab.yaml e lab_es.yaml
+package labscaffold
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/labschema"
+)
+
+// Limites do número de tarefas do esqueleto
+const (
+	MinTasks = 1
+	MaxTasks = 20
+)
+
+// Category reúne os valores padrão de uma categoria de laboratórios
+type Category struct {
+	Image      string
+	Privileged bool
+	// Type é o tipo de ambiente do laboratório, quando o backend precisa dele
+	Type string
+}
+
+// Categories são as categorias usadas nos laboratórios do repositório, com a
+// imagem e as opções de ambiente de cada uma
+var Categories = map[string]Category{
+	"linux":      {Image: "linuxtips/girus-devops:0.1"},
+	"docker":     {Image: "linuxtips/girus-devops:0.1", Privileged: true},
+	"kubernetes": {Image: "linuxtips/girus-kind-single-node:0.1"},
+	"aws":        {Image: "linuxtips/girus-localstack:0.1", Privileged: true, Type: "aws"},
+	"terraform":  {Image: "linuxtips/girus-localstack:0.1", Privileged: true, Type: "terraform"},
+}
+
+// CategoryNames retorna os nomes das categorias em ordem alfabética
+func CategoryNames() []string {
+	names := make([]string, 0, len(Categories))
+	for name := range Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Options são as respostas usadas para gerar o laboratório
+type Options struct {
+	// ID é o nome do diretório do laboratório, como linux_meu-lab
+	ID       string
+	Title    string
+	Duration string
+	Image    string
+	Category string
+	Tasks    int
+}
+
+// CategoryOf retorna a categoria indicada pelo prefixo do ID (linux_meu-lab
+// é da categoria linux), ou vazio quando o prefixo não é uma categoria
+func CategoryOf(id string) string {
+	prefix, _, found := strings.Cut(id, "_")
+	if _, known := Categories[prefix]; found && known {
+		return prefix
+	}
+	return ""
+}
+
+// Name retorna o nome do laboratório derivado do ID, no formato dos nomes
+// dos ConfigMaps (linux_meu-lab vira linux-meu-lab)
+func (o Options) Name() string {
+	return strings.ReplaceAll(o.ID, "_", "-")
+}
+
+// Validate confere as opções antes da geração
+func (o Options) Validate() error {
+	if !idPattern.MatchString(o.ID) {
+		return fmt.Errorf("ID inválido %q: use letras minúsculas, números, - e _, como linux_meu-lab", o.ID)
+	}
+	if strings.TrimSpace(o.Title) == "" {
+		return fmt.Errorf("o título do laboratório é obrigatório")
+	}
+	if d, err := time.ParseDuration(o.Duration); err != nil || d <= 0 {
+		return fmt.Errorf("duração inválida %q: use um valor como 30m ou 1h30m", o.Duration)
+	}
+	if _, ok := Categories[o.Category]; !ok {
+		return fmt.Errorf("categoria desconhecida %q: use %s", o.Category, strings.Join(CategoryNames(), ", "))
+	}
+	if strings.TrimSpace(o.Image) == "" {
+		return fmt.Errorf("a imagem base é obrigatória")
+	}
+	if o.Tasks < MinTasks || o.Tasks > MaxTasks {
+		return fmt.Errorf("número de tarefas inválido %d: use de %d a %d", o.Tasks, MinTasks, MaxTasks)
+	}
+	return nil
+}
+
+// texts são os textos do esqueleto em um idioma
+type texts struct {
+	Suffix      string
+	Description string
+	Task        string
+	TaskDesc    string
+	Step        string
+	TipTitle    string
+	TipContent  string
+	Error       string
+}
+
+var languages = map[string]texts{
+	"pt": {
+		Description: "Descreva aqui o que o aluno vai aprender neste laboratório.",
+		Task:        "Tarefa %d",
+		TaskDesc:    "Descreva aqui o objetivo da tarefa %d.",
+		Step:        "Explique o que o comando abaixo faz:",
+		TipTitle:    "Dica",
+		TipContent:  "Escreva aqui uma dica para a tarefa %d.",
+		Error:       "O arquivo /tmp/tarefa-%d não foi encontrado. Execute o comando dos passos.",
+	},
+	"es": {
+		Suffix:      "-es",
+		Description: "Describa aquí lo que el alumno aprenderá en este laboratorio.",
+		Task:        "Tarea %d",
+		TaskDesc:    "Describa aquí el objetivo de la tarea %d.",
+		Step:        "Explique lo que hace el siguiente comando:",
+		TipTitle:    "Consejo",
+		TipContent:  "Escriba aquí un consejo para la tarea %d.",
+		Error:       "No se encontró el archivo /tmp/tarefa-%d. Ejecute el comando de los pasos.",
+	},
+}
+
+// manifest é o ConfigMap gerado, no mesmo formato dos laboratórios do
+// repositório: o lab.yaml como bloco literal e os textos entre aspas
+var manifest = template.Must(template.New("lab").Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{.Name}}-lab{{.Text.Suffix}}
+  namespace: girus
+  labels:
+    app: ` + labschema.TemplateLabel + `
+data:
+  ` + labschema.DataKey + `: |
+    schemaVersion: ` + labschema.Version + `
+    name: {{.Name}}{{.Text.Suffix}}
+    title: {{quote .Title}}
+    description: {{quote .Text.Description}}
+    duration: {{.Duration}}
+    image: {{quote .Image}}
+{{- if .Category.Privileged}}
+    privileged: true
+{{- end}}
+{{- if .Category.Type}}
+    type: {{quote .Category.Type}}
+{{- end}}
+    tasks:
+{{- range .Tasks}}
+      - name: {{quote (printf $.Text.Task .)}}
+        description: {{quote (printf $.Text.TaskDesc .)}}
+        steps:
+          - {{quote $.Text.Step}}
+          - "` + "`touch /tmp/tarefa-{{.}}`" + `"
+        tips:
+          - type: "info"
+            title: {{quote $.Text.TipTitle}}
+            content: {{quote (printf $.Text.TipContent .)}}
+        solution: "touch /tmp/tarefa-{{.}}"
+        validation:
+          - command: "test -f /tmp/tarefa-{{.}} && echo ok"
+            expectedOutput: "ok"
+            errorMessage: {{quote (printf $.Text.Error .)}}
+{{- end}}
+`))
+
+// Render gera o ConfigMap do laboratório no idioma informado (pt ou es)
+func Render(o Options, lang string) ([]byte, error) {
+	text, ok := languages[lang]
+	if !ok {
+		return nil, fmt.Errorf("idioma não suportado %q", lang)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	tasks := make([]int, o.Tasks)
+	for i := range tasks {
+		tasks[i] = i + 1
+	}
+
+	var buf bytes.Buffer
+	err := manifest.Execute(&buf, map[string]any{
+		"Name":     o.Name(),
+		"Title":    o.Title,
+		"Duration": o.Duration,
+		"Image":    o.Image,
+		"Category": Categories[o.Category],
+		"Tasks":    tasks,
+		"Text":     text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("falha ao gerar o laboratório: %w", err)
+	}
+	// O esqueleto gerado precisa passar no mesmo schema de 'girus lab validate'
+	if _, err := labschema.Load(buf.Bytes()); err != nil {
+		return nil, fmt.Errorf("o laboratório gerado não segue o schema: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Files são os arquivos gerados por Write, relativos ao diretório base
+var Files = map[string]string{"pt": "lab.yaml", "es": "lab_es.yaml"}
+
+// Write gera os arquivos do laboratório em <dir>/<id>, retornando os
+// caminhos criados. Arquivos existentes só são sobrescritos com force.
+func Write(dir string, o Options, force bool) ([]string, error) {
+	target := filepath.Join(dir, o.ID)
+	contents := map[string][]byte{}
+	for _, lang := range []string{"pt", "es"} {
+		data, err := Render(o, lang)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(target, Files[lang])
+		if _, err := os.Stat(path); err == nil && !force {
+			return nil, fmt.Errorf("o arquivo %s já existe (use --force para sobrescrever)", path)
+		}
+		contents[path] = data
+	}
+
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return nil, fmt.Errorf("falha ao criar o diretório %s: %w", target, err)
+	}
+	var paths []string
+	for _, lang := range []string{"pt", "es"} {
+		path := filepath.Join(target, Files[lang])
+		if err := os.WriteFile(path, contents[path], 0644); err != nil {
+			return nil, fmt.Errorf("falha ao gravar %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
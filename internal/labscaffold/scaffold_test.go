@@ -0,0 +1,83 @@
+package labscaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/lablint"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+)
+
+func TestRender(t *testing.T) {
+	options := Options{ID: "docker_meu-lab", Title: `Redes "avançadas"`, Duration: "45m", Image: "nginx:1.27", Category: "docker", Tasks: 3}
+	for _, lang := range []string{"pt", "es"} {
+		data, err := Render(options, lang)
+		if err != nil {
+			t.Fatalf("%s: Render retornou erro: %v", lang, err)
+		}
+		doc, err := labschema.Load(data)
+		if err != nil {
+			t.Fatalf("%s: o laboratório gerado é inválido: %v", lang, err)
+		}
+		if doc.Labels["app"] != labschema.TemplateLabel {
+			t.Errorf("%s: label app = %q", lang, doc.Labels["app"])
+		}
+		if doc.Lab.Title != options.Title || doc.Lab.Image != options.Image || !doc.Lab.Privileged || len(doc.Lab.Tasks) != 3 {
+			t.Errorf("%s: laboratório gerado diferente das opções: %+v", lang, doc.Lab)
+		}
+		if findings := lablint.Lint(data, nil); len(findings) > 0 {
+			t.Errorf("%s: o esqueleto não deveria ter problemas de lint: %v", lang, findings)
+		}
+	}
+
+	es, _ := Render(options, "es")
+	if doc, _ := labschema.Load(es); doc.Name != "docker-meu-lab-lab-es" || doc.Lab.Name != "docker-meu-lab-es" {
+		t.Errorf("nomes da variante es = %q e %q", doc.Name, doc.Lab.Name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Options{ID: "linux_lab", Title: "Lab", Duration: "30m", Image: "ubuntu", Category: "linux", Tasks: 1}
+	tests := map[string]func(o *Options){
+		"ID":        func(o *Options) { o.ID = "Meu Lab" },
+		"título":    func(o *Options) { o.Title = " " },
+		"duração":   func(o *Options) { o.Duration = "meia hora" },
+		"categoria": func(o *Options) { o.Category = "cobol" },
+		"tarefas":   func(o *Options) { o.Tasks = MaxTasks + 1 },
+	}
+	for name, change := range tests {
+		options := valid
+		change(&options)
+		if err := options.Validate(); err == nil {
+			t.Errorf("%s inválido deveria ser recusado", name)
+		}
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("opções válidas recusadas: %v", err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	options := Options{ID: "linux_lab", Title: "Lab", Duration: "30m", Image: "ubuntu", Category: "linux", Tasks: 1}
+	paths, err := Write(dir, options, false)
+	if err != nil {
+		t.Fatalf("Write retornou erro: %v", err)
+	}
+	for i, name := range []string{"lab.yaml", "lab_es.yaml"} {
+		if paths[i] != filepath.Join(dir, "linux_lab", name) {
+			t.Errorf("caminho %d = %s", i, paths[i])
+		}
+		if _, err := os.Stat(paths[i]); err != nil {
+			t.Errorf("arquivo não criado: %v", err)
+		}
+	}
+	if _, err := Write(dir, options, false); err == nil || !strings.Contains(err.Error(), "já existe") {
+		t.Errorf("arquivos existentes não deveriam ser sobrescritos sem force: %v", err)
+	}
+	if _, err := Write(dir, options, true); err != nil {
+		t.Errorf("com force os arquivos deveriam ser sobrescritos: %v", err)
+	}
+}
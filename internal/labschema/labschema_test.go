@@ -0,0 +1,147 @@
+package labschema
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/templates"
+)
+
+func TestEmbeddedTemplatesAreValid(t *testing.T) {
+	for _, dir := range []string{"manifests", "manifests_es"} {
+		entries, err := fs.ReadDir(templates.ManifestFS, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), "lab_") {
+				continue
+			}
+			name := path.Join(dir, entry.Name())
+			data, err := fs.ReadFile(templates.ManifestFS, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, issues := Validate(data); len(issues) > 0 {
+				t.Errorf("%s: %v", name, issues)
+			}
+		}
+	}
+}
+
+const invalidLab = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: exemplo-lab
+  labels:
+    app: girus-lab-template
+data:
+  lab.yaml: |
+    name: exemplo
+    title: "Exemplo"
+    description: "Laboratório de exemplo"
+    duration: trinta minutos
+    image: "ubuntu:22.04"
+    tasks:
+      - name: "Primeira tarefa"
+        description: "Descrição"
+        steps:
+          - "` + "`ls`" + `"
+        tips:
+          - type: "aviso"
+            title: "Dica"
+            content: "Conteúdo"
+        validation: []
+`
+
+func TestValidateReportsPositions(t *testing.T) {
+	_, issues := Validate([]byte(invalidLab))
+
+	want := []string{
+		`12:15: duration: duração inválida "trinta minutos": use um valor como 30m ou 1h30m`,
+		`20:19: tasks[0].tips[0].type: tipo de dica desconhecido "aviso": use info, tip, warning`,
+		`23:21: tasks[0].validation: validation está vazia: remova o campo ou adicione ao menos uma verificação`,
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("problemas inesperados:\n%s\nesperado:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateMissingTasks(t *testing.T) {
+	data := strings.Replace(invalidLab, "    tasks:", "    outras:", 1)
+	_, issues := Validate([]byte(data))
+
+	found := false
+	for _, issue := range issues {
+		if issue.Path == "tasks" && issue.Line == 9 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("a ausência de tasks deveria ser apontada na linha 9: %v", issues)
+	}
+}
+
+func TestSyntaxErrorPosition(t *testing.T) {
+	data := strings.Replace(invalidLab, `title: "Exemplo"`, `title: "Um "Exemplo""`, 1)
+	_, issues := Validate([]byte(data))
+	if len(issues) != 1 || issues[0].Line != 10 {
+		t.Errorf("o erro de sintaxe deveria apontar a linha 10 do arquivo: %v", issues)
+	}
+}
+
+// TestJSONSchemaMatchesTypes garante que o JSON Schema publicado descreve os
+// mesmos campos dos tipos Go
+func TestJSONSchemaMatchesTypes(t *testing.T) {
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+		Defs       map[string]struct {
+			Properties map[string]any `json:"properties"`
+			OneOf      []struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"oneOf"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(JSONSchema, &schema); err != nil {
+		t.Fatalf("JSON Schema inválido: %v", err)
+	}
+
+	cases := map[string]struct {
+		typ        any
+		properties map[string]any
+	}{
+		"Lab":        {Lab{}, schema.Properties},
+		"Task":       {Task{}, schema.Defs["task"].Properties},
+		"Step":       {Step{}, schema.Defs["step"].OneOf[1].Properties},
+		"Tip":        {Tip{}, schema.Defs["tip"].Properties},
+		"Validation": {Validation{}, schema.Defs["validation"].Properties},
+	}
+	for name, c := range cases {
+		typ := reflect.TypeOf(c.typ)
+		var fields []string
+		for i := 0; i < typ.NumField(); i++ {
+			tag := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+			if tag != "-" {
+				fields = append(fields, tag)
+			}
+		}
+		var properties []string
+		for property := range c.properties {
+			properties = append(properties, property)
+		}
+		slices.Sort(fields)
+		slices.Sort(properties)
+		if !slices.Equal(fields, properties) {
+			t.Errorf("%s: campos %v, propriedades no schema %v", name, fields, properties)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package labschema
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Namespace é o namespace dos ConfigMaps gerados pelo CLI
+const Namespace = "girus"
+
+// Anotações de proveniência dos ConfigMaps gerados a partir de um lab.yaml puro
+const (
+	// AnnotationSource guarda o arquivo ou a URL de onde o laboratório veio
+	AnnotationSource = "girus.linuxtips.io/source"
+	// AnnotationGeneratedBy guarda a versão do CLI que gerou o ConfigMap
+	AnnotationGeneratedBy = "girus.linuxtips.io/generated-by"
+	// AnnotationChecksum guarda o SHA-256 do lab.yaml original
+	AnnotationChecksum = "girus.linuxtips.io/lab-sha256"
+)
+
+// Provenance identifica a origem de um laboratório empacotado pelo CLI
+type Provenance struct {
+	Source      string
+	GeneratedBy string
+}
+
+// resourceName é o formato dos nomes de objetos do Kubernetes (RFC 1123)
+var resourceName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// configMap é o ConfigMap gerado, com os campos na ordem dos manifestos do
+// repositório
+type configMap struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name        string            `yaml:"name"`
+		Namespace   string            `yaml:"namespace"`
+		Labels      map[string]string `yaml:"labels"`
+		Annotations map[string]string `yaml:"annotations,omitempty"`
+	} `yaml:"metadata"`
+	Data map[string]string `yaml:"data"`
+}
+
+// ConfigMapName retorna o nome do ConfigMap de um laboratório, seguindo a
+// convenção dos manifestos: linux-basico vira linux-basico-lab e a variante
+// linux-basico-es vira linux-basico-lab-es
+func ConfigMapName(labName string) string {
+	if base, found := strings.CutSuffix(labName, "-es"); found {
+		return base + "-lab-es"
+	}
+	return labName + "-lab"
+}
+
+// Manifest valida o laboratório em data e retorna o ConfigMap a aplicar no
+// cluster. Um ConfigMap de template é retornado sem alterações; um lab.yaml
+// puro é empacotado em um ConfigMap com nome, namespace, label e as anotações
+// de proveniência. O documento retornado traz o metadata do ConfigMap.
+func Manifest(data []byte, p Provenance) ([]byte, *Document, error) {
+	doc, err := Load(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !doc.Bare {
+		return data, doc, nil
+	}
+
+	name := ConfigMapName(doc.Lab.Name)
+	if len(name) > 253 || !resourceName.MatchString(name) {
+		return nil, nil, fmt.Errorf("o nome do laboratório %q não pode ser usado no ConfigMap: use letras minúsculas, números e -", doc.Lab.Name)
+	}
+
+	var cm configMap
+	cm.APIVersion = "v1"
+	cm.Kind = "ConfigMap"
+	cm.Metadata.Name = name
+	cm.Metadata.Namespace = Namespace
+	cm.Metadata.Labels = map[string]string{"app": TemplateLabel}
+	checksum := sha256.Sum256(data)
+	cm.Metadata.Annotations = map[string]string{AnnotationChecksum: hex.EncodeToString(checksum[:])}
+	if p.Source != "" {
+		cm.Metadata.Annotations[AnnotationSource] = p.Source
+	}
+	if p.GeneratedBy != "" {
+		cm.Metadata.Annotations[AnnotationGeneratedBy] = p.GeneratedBy
+	}
+	cm.Data = map[string]string{DataKey: string(data)}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cm); err != nil {
+		return nil, nil, fmt.Errorf("falha ao gerar o ConfigMap do laboratório: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, fmt.Errorf("falha ao gerar o ConfigMap do laboratório: %w", err)
+	}
+
+	doc.Name = cm.Metadata.Name
+	doc.Namespace = cm.Metadata.Namespace
+	doc.Labels = cm.Metadata.Labels
+	return buf.Bytes(), doc, nil
+}
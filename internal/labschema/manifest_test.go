@@ -0,0 +1,77 @@
+package labschema
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const bareLab = `# Comentários do autor são preservados no ConfigMap
+name: exemplo-es
+title: "Ejemplo"
+description: "Laboratorio de ejemplo"
+duration: 30m
+image: "ubuntu:22.04"
+tasks:
+  - name: "Primera tarea"
+    description: "Descripción"
+    steps:
+      - "` + "`ls`" + `"
+    validation:
+      - command: "test -d /tmp && echo ok"
+        expectedOutput: "ok"
+`
+
+func TestManifestWrapsBareLab(t *testing.T) {
+	manifest, doc, err := Manifest([]byte(bareLab), Provenance{Source: "labs/exemplo/lab_es.yaml", GeneratedBy: "girus-cli dev"})
+	if err != nil {
+		t.Fatalf("Manifest retornou erro: %v", err)
+	}
+	if !doc.Bare || doc.Name != "exemplo-lab-es" || doc.Namespace != Namespace {
+		t.Errorf("metadata inesperado: bare=%v nome=%q namespace=%q", doc.Bare, doc.Name, doc.Namespace)
+	}
+
+	wrapped, err := Load(manifest)
+	if err != nil {
+		t.Fatalf("o ConfigMap gerado é inválido: %v\n%s", err, manifest)
+	}
+	if wrapped.Bare || wrapped.Labels["app"] != TemplateLabel || wrapped.Lab.Title != "Ejemplo" {
+		t.Errorf("ConfigMap gerado inesperado:\n%s", manifest)
+	}
+	for _, want := range []string{AnnotationSource + ": labs/exemplo/lab_es.yaml", AnnotationGeneratedBy + ": girus-cli dev", AnnotationChecksum + ": ", "# Comentários do autor"} {
+		if !strings.Contains(string(manifest), want) {
+			t.Errorf("o ConfigMap gerado deveria conter %q:\n%s", want, manifest)
+		}
+	}
+}
+
+func TestManifestKeepsConfigMap(t *testing.T) {
+	data := []byte(strings.Replace(invalidLab, "duration: trinta minutos", "duration: 30m", 1))
+	data = bytes.Replace(data, []byte(`"aviso"`), []byte(`"warning"`), 1)
+	data = bytes.Replace(data, []byte("validation: []"), []byte(""), 1)
+	manifest, doc, err := Manifest(data, Provenance{Source: "lab.yaml"})
+	if err != nil {
+		t.Fatalf("Manifest retornou erro: %v", err)
+	}
+	if doc.Bare || !bytes.Equal(manifest, data) {
+		t.Errorf("o ConfigMap deveria ser aplicado sem alterações:\n%s", manifest)
+	}
+}
+
+func TestValidateBareLabPositions(t *testing.T) {
+	data := strings.Replace(bareLab, "duration: 30m", "duration: meia hora", 1)
+	_, issues := Validate([]byte(data))
+	if len(issues) != 1 || issues[0].String() != `5:11: duration: duração inválida "meia hora": use um valor como 30m ou 1h30m` {
+		t.Errorf("problemas inesperados: %v", issues)
+	}
+	if !IsTemplate([]byte(bareLab)) || IsTemplate([]byte("name: outro\nvalues: {}\n")) {
+		t.Error("IsTemplate deveria reconhecer apenas o lab.yaml com tasks")
+	}
+}
+
+func TestManifestRejectsInvalidName(t *testing.T) {
+	data := strings.Replace(bareLab, "name: exemplo-es", "name: Exemplo_ES", 1)
+	if _, _, err := Manifest([]byte(data), Provenance{}); err == nil {
+		t.Error("nomes fora do padrão do Kubernetes deveriam ser recusados")
+	}
+}
@@ -0,0 +1,180 @@
+// Package labschema define o formato versionado dos templates de laboratório
+// do Girus: o documento lab.yaml guardado em um ConfigMap com a label
+// app: girus-lab-template.
+package labschema
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version é a versão atual do schema dos laboratórios. Documentos sem o campo
+// schemaVersion são tratados como desta versão.
+const Version = "v1"
+
+// TemplateLabel é o valor da label app dos ConfigMaps de laboratório
+const TemplateLabel = "girus-lab-template"
+
+// DataKey é a chave do ConfigMap que guarda o documento do laboratório
+const DataKey = "lab.yaml"
+
+// TipTypes são os tipos de dica exibidos pelo frontend
+var TipTypes = []string{"info", "tip", "warning"}
+
+// JSONSchema é o JSON Schema publicado do documento lab.yaml
+//
+//go:embed lab.v1.schema.json
+var JSONSchema []byte
+
+// Lab é o documento lab.yaml de um template de laboratório
+type Lab struct {
+	SchemaVersion string `yaml:"schemaVersion,omitempty" json:"schemaVersion,omitempty"`
+	Name          string `yaml:"name" json:"name"`
+	Title         string `yaml:"title" json:"title"`
+	Description   string `yaml:"description" json:"description"`
+	Duration      string `yaml:"duration" json:"duration"`
+	MaxDuration   string `yaml:"maxDuration,omitempty" json:"maxDuration,omitempty"`
+	TimerEnabled  bool   `yaml:"timerEnabled,omitempty" json:"timerEnabled,omitempty"`
+	Image         string `yaml:"image" json:"image"`
+	Privileged    bool   `yaml:"privileged,omitempty" json:"privileged,omitempty"`
+	Type          string `yaml:"type,omitempty" json:"type,omitempty"`
+	Entrypoint    string `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"`
+	YoutubeVideo  string `yaml:"youtubeVideo,omitempty" json:"youtubeVideo,omitempty"`
+	Tasks         []Task `yaml:"tasks" json:"tasks"`
+}
+
+// Task é uma tarefa do laboratório
+type Task struct {
+	Name        string       `yaml:"name" json:"name"`
+	Description string       `yaml:"description" json:"description"`
+	Steps       []Step       `yaml:"steps" json:"steps"`
+	Tips        []Tip        `yaml:"tips,omitempty" json:"tips,omitempty"`
+	Validation  []Validation `yaml:"validation,omitempty" json:"validation,omitempty"`
+	// Solution é o script que resolve a tarefa. O frontend não o exibe; ele
+	// é executado pelo 'girus lab test' antes das validações.
+	Solution string `yaml:"solution,omitempty" json:"solution,omitempty"`
+}
+
+// Step é um passo da tarefa. Na forma simples o passo é apenas um texto; na
+// forma detalhada traz o comando, a saída esperada e uma dica.
+type Step struct {
+	Text           string `yaml:"-" json:"-"`
+	Description    string `yaml:"description,omitempty" json:"description,omitempty"`
+	Command        string `yaml:"command,omitempty" json:"command,omitempty"`
+	ExpectedOutput string `yaml:"expectedOutput,omitempty" json:"expectedOutput,omitempty"`
+	Hint           string `yaml:"hint,omitempty" json:"hint,omitempty"`
+}
+
+// Tip é uma dica exibida junto da tarefa
+type Tip struct {
+	Type    string `yaml:"type" json:"type"`
+	Title   string `yaml:"title" json:"title"`
+	Content string `yaml:"content" json:"content"`
+}
+
+// Validation é uma verificação executada no ambiente do aluno para concluir a
+// tarefa. A saída do comando é comparada com expectedOutput ou avaliada por
+// expectedExpression (como ">= 2").
+type Validation struct {
+	Command            string `yaml:"command" json:"command"`
+	ExpectedOutput     string `yaml:"expectedOutput,omitempty" json:"expectedOutput,omitempty"`
+	ExpectedExpression string `yaml:"expectedExpression,omitempty" json:"expectedExpression,omitempty"`
+	ErrorMessage       string `yaml:"errorMessage,omitempty" json:"errorMessage,omitempty"`
+}
+
+// stepFields evita a recursão de UnmarshalYAML e MarshalYAML
+type stepFields Step
+
+// UnmarshalYAML aceita o passo como texto ou como objeto
+func (s *Step) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = Step{Text: node.Value}
+		return nil
+	}
+	var fields stepFields
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+	*s = Step(fields)
+	return nil
+}
+
+// MarshalYAML grava o passo simples como texto
+func (s Step) MarshalYAML() (interface{}, error) {
+	if s.Text != "" {
+		return s.Text, nil
+	}
+	return stepFields(s), nil
+}
+
+// Document é um template de laboratório já lido
+type Document struct {
+	// Name, Namespace e Labels vêm do metadata do ConfigMap
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Lab       Lab
+	// Node é a raiz do lab.yaml, com as posições no arquivo original
+	Node *yaml.Node
+	// ExactPositions informa se as posições dos nós correspondem exatamente
+	// ao arquivo, o que só acontece quando o lab.yaml é um bloco | ou >
+	ExactPositions bool
+	// Bare informa se o arquivo é o lab.yaml puro, sem o ConfigMap
+	Bare bool
+}
+
+// ValidationError reúne os problemas que impedem o uso de um laboratório
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Issues) == 1 {
+		return fmt.Sprintf("laboratório inválido: %s", e.Issues[0])
+	}
+	return fmt.Sprintf("laboratório inválido: %s (e mais %d problemas)", e.Issues[0], len(e.Issues)-1)
+}
+
+// Load lê um ConfigMap de template de laboratório e o valida contra o schema.
+// Problemas de validação são retornados como *ValidationError.
+func Load(data []byte) (*Document, error) {
+	doc, issues := Validate(data)
+	if len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
+	}
+	return doc, nil
+}
+
+// IsTemplate informa se o primeiro documento de data é um ConfigMap de
+// template de laboratório ou um lab.yaml puro, válido ou não. Arquivos que
+// nem podem ser lidos como YAML também são considerados templates, para que
+// o erro de sintaxe seja informado a quem percorre um diretório de
+// laboratórios.
+func IsTemplate(data []byte) bool {
+	var object struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Labels map[string]string `yaml:"labels"`
+		} `yaml:"metadata"`
+		Tasks *yaml.Node `yaml:"tasks"`
+	}
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&object); err != nil {
+		return true
+	}
+	if object.Kind == "" {
+		return object.Tasks != nil
+	}
+	return object.Kind == "ConfigMap" && object.Metadata.Labels["app"] == TemplateLabel
+}
+
+// ParseLab lê um documento lab.yaml sem validá-lo
+func ParseLab(data []byte) (*Lab, error) {
+	var lab Lab
+	if err := yaml.Unmarshal(data, &lab); err != nil {
+		return nil, fmt.Errorf("falha ao ler o lab.yaml: %w", err)
+	}
+	return &lab, nil
+}
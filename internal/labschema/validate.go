@@ -0,0 +1,432 @@
+package labschema
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Issue é um problema encontrado em um arquivo de laboratório. Line e Column
+// se referem ao arquivo original, inclusive dentro do bloco lab.yaml.
+type Issue struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Path    string `json:"path,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Códigos dos problemas, que permitem tratar cada tipo de problema
+// separadamente (como faz o 'girus lab lint')
+const (
+	CodeSyntax       = "syntax"
+	CodeConfigMap    = "configmap"
+	CodeRequired     = "required"
+	CodeUnknownField = "unknown-field"
+	CodeType         = "type"
+	CodeEmpty        = "empty"
+	CodeDuration     = "duration"
+	CodeTipType      = "tip-type"
+	CodeVersion      = "schema-version"
+)
+
+func (i Issue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", i.Line, i.Column, i.Path, i.Message)
+}
+
+// errorLine extrai a linha das mensagens de erro do yaml.v3
+var errorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// validator acumula os problemas encontrados ao percorrer o documento
+type validator struct {
+	issues []Issue
+}
+
+// add registra um problema na posição do nó
+func (v *validator) add(code string, node *yaml.Node, path, format string, args ...any) {
+	v.issues = append(v.issues, Issue{Line: node.Line, Column: node.Column, Path: path, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate confere um ConfigMap de template de laboratório, ou um lab.yaml
+// puro, contra o schema e retorna o documento lido junto dos problemas
+// encontrados. O documento é nil
+// quando o arquivo nem chega a ser lido como laboratório.
+func Validate(data []byte) (*Document, []Issue) {
+	v := &validator{}
+
+	var file yaml.Node
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		v.syntaxError(err, data, data, 0)
+		return nil, v.issues
+	}
+	if len(file.Content) == 0 {
+		v.issues = append(v.issues, Issue{Line: 1, Column: 1, Code: CodeEmpty, Message: "arquivo vazio"})
+		return nil, v.issues
+	}
+
+	root := file.Content[0]
+	if root.Kind != yaml.MappingNode {
+		v.add(CodeConfigMap, root, "", "o arquivo deve ser um lab.yaml ou um ConfigMap com o laboratório em data.%s", DataKey)
+		return nil, v.issues
+	}
+
+	doc := &Document{}
+	// Sem kind, o arquivo é o próprio lab.yaml, que o CLI empacota no ConfigMap
+	if value(root, "kind") == nil && value(root, "tasks") != nil {
+		doc.Node = root
+		doc.Bare = true
+		doc.ExactPositions = true
+		v.lab(doc.Node)
+		if err := doc.Node.Decode(&doc.Lab); err != nil && len(v.issues) == 0 {
+			v.syntaxError(err, nil, data, 0)
+		}
+		return doc, v.issues
+	}
+	if kind := value(root, "kind"); kind == nil || kind.Value != "ConfigMap" {
+		v.add(CodeConfigMap, positionOf(kind, root), "kind", "o arquivo deve ser um lab.yaml ou um ConfigMap com o laboratório em data.%s", DataKey)
+		return nil, v.issues
+	}
+
+	metadata := value(root, "metadata")
+	if metadata == nil || metadata.Kind != yaml.MappingNode {
+		v.add(CodeRequired, positionOf(metadata, root), "metadata", "campo obrigatório ausente")
+	} else {
+		if name := value(metadata, "name"); name == nil || name.Value == "" {
+			v.add(CodeRequired, positionOf(name, metadata), "metadata.name", "campo obrigatório ausente")
+		} else {
+			doc.Name = name.Value
+		}
+		if namespace := value(metadata, "namespace"); namespace != nil {
+			doc.Namespace = namespace.Value
+		}
+		labels := value(metadata, "labels")
+		if labels != nil {
+			_ = labels.Decode(&doc.Labels)
+		}
+		if doc.Labels["app"] != TemplateLabel {
+			v.add(CodeConfigMap, positionOf(labels, metadata), "metadata.labels", "o ConfigMap precisa da label app: %s", TemplateLabel)
+		}
+	}
+
+	dataNode := value(root, "data")
+	var labNode *yaml.Node
+	if dataNode != nil && dataNode.Kind == yaml.MappingNode {
+		labNode = value(dataNode, DataKey)
+	}
+	if labNode == nil || labNode.Kind != yaml.ScalarNode {
+		v.add(CodeRequired, positionOf(labNode, positionOf(dataNode, root)), "data."+DataKey, "campo obrigatório ausente")
+		return nil, v.issues
+	}
+
+	lineOffset, columnOffset := blockOffset(data, labNode)
+	var inner yaml.Node
+	if err := yaml.Unmarshal([]byte(labNode.Value), &inner); err != nil {
+		v.syntaxError(err, []byte(labNode.Value), data, lineOffset)
+		return nil, v.issues
+	}
+	if len(inner.Content) == 0 {
+		v.add(CodeEmpty, labNode, "data."+DataKey, "o documento do laboratório está vazio")
+		return nil, v.issues
+	}
+
+	doc.Node = inner.Content[0]
+	doc.ExactPositions = lineOffset != 0
+	shift(doc.Node, labNode, lineOffset, columnOffset)
+	v.lab(doc.Node)
+
+	if err := doc.Node.Decode(&doc.Lab); err != nil && len(v.issues) == 0 {
+		v.syntaxError(err, nil, data, 0)
+	}
+	return doc, v.issues
+}
+
+// syntaxError converte um erro do yaml.v3 em problemas. src é o documento
+// que falhou e lineOffset o deslocamento dele dentro de data, o arquivo
+// original.
+func (v *validator) syntaxError(err error, src, data []byte, lineOffset int) {
+	messages := []string{err.Error()}
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		messages = typeErr.Errors
+	}
+	for _, message := range messages {
+		issue := Issue{Line: lineOffset + 1, Code: CodeSyntax, Message: "YAML inválido: " + strings.TrimPrefix(message, "yaml: ")}
+		if match := errorLine.FindStringSubmatch(message); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			// Nos erros do parser o yaml.v3 informa o início da coleção que
+			// falhou, e não a linha do problema
+			if strings.HasPrefix(match[2], "did not find expected") {
+				line = problemLine(src)
+			}
+			issue.Line = lineOffset + line
+			issue.Message = "YAML inválido: " + match[2]
+		}
+		issue.Column = firstColumn(data, issue.Line)
+		v.issues = append(v.issues, issue)
+	}
+}
+
+// problemLine encontra a primeira linha a partir da qual o documento deixa de
+// ser válido, lendo trechos cada vez maiores do início do documento
+func problemLine(src []byte) int {
+	lines := bytes.SplitAfter(src, []byte("\n"))
+	return sort.Search(len(lines), func(n int) bool {
+		var node yaml.Node
+		err := yaml.Unmarshal(bytes.Join(lines[:n+1], nil), &node)
+		return err != nil && !strings.Contains(err.Error(), "end of stream")
+	}) + 1
+}
+
+// firstColumn retorna a coluna do primeiro caractere da linha
+func firstColumn(data []byte, line int) int {
+	lines := bytes.Split(data, []byte("\n"))
+	if line < 1 || line > len(lines) {
+		return 1
+	}
+	return len(lines[line-1]) - len(bytes.TrimLeft(lines[line-1], " ")) + 1
+}
+
+// blockOffset calcula quanto somar às posições do documento lab.yaml para
+// chegar às posições no arquivo. Só blocos literais (|) ou dobrados (>)
+// preservam a correspondência entre as linhas; nos demais estilos o
+// deslocamento é zero e shift usa a posição do próprio valor.
+func blockOffset(data []byte, node *yaml.Node) (int, int) {
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+		return 0, 0
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	for i := node.Line; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := bytes.TrimLeft(line, " ")
+		if len(bytes.TrimSpace(trimmed)) == 0 {
+			continue
+		}
+		return node.Line, len(line) - len(trimmed)
+	}
+	return node.Line, 0
+}
+
+// shift traduz as posições dos nós do lab.yaml para o arquivo original
+func shift(node, block *yaml.Node, lineOffset, columnOffset int) {
+	if lineOffset == 0 {
+		node.Line, node.Column = block.Line, block.Column
+	} else {
+		node.Line += lineOffset
+		node.Column += columnOffset
+	}
+	for _, child := range node.Content {
+		shift(child, block, lineOffset, columnOffset)
+	}
+}
+
+// value retorna o valor da chave em um mapeamento, ou nil
+func value(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// positionOf retorna node, ou parent quando node não existe
+func positionOf(node, parent *yaml.Node) *yaml.Node {
+	if node != nil {
+		return node
+	}
+	return parent
+}
+
+// fieldCheck valida o valor de um campo do mapeamento
+type fieldCheck func(node *yaml.Node, path string)
+
+// mapping confere um objeto: campos desconhecidos, obrigatórios e o valor de
+// cada campo presente
+func (v *validator) mapping(node *yaml.Node, path string, fields map[string]fieldCheck, required ...string) {
+	if node.Kind != yaml.MappingNode {
+		v.add(CodeType, node, path, "deve ser um objeto")
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, val := node.Content[i], node.Content[i+1]
+		check, ok := fields[key.Value]
+		if !ok {
+			v.add(CodeUnknownField, key, join(path, key.Value), "campo desconhecido")
+			continue
+		}
+		check(val, join(path, key.Value))
+	}
+	for _, field := range required {
+		if value(node, field) == nil {
+			v.add(CodeRequired, node, join(path, field), "campo obrigatório ausente")
+		}
+	}
+}
+
+// sequence confere uma lista e cada um dos seus itens
+func (v *validator) sequence(node *yaml.Node, path string, item fieldCheck) {
+	if node.Kind != yaml.SequenceNode {
+		v.add(CodeType, node, path, "deve ser uma lista")
+		return
+	}
+	for i, child := range node.Content {
+		item(child, fmt.Sprintf("%s[%d]", path, i))
+	}
+}
+
+// text confere um texto não vazio
+func (v *validator) text(node *yaml.Node, path string) {
+	if node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		v.add(CodeType, node, path, "deve ser um texto")
+		return
+	}
+	if strings.TrimSpace(node.Value) == "" {
+		v.add(CodeEmpty, node, path, "não pode ser vazio")
+	}
+}
+
+// str confere um texto que pode ser vazio
+func (v *validator) str(node *yaml.Node, path string) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		v.add(CodeType, node, path, "deve ser um texto")
+	}
+}
+
+// boolean confere um valor true ou false
+func (v *validator) boolean(node *yaml.Node, path string) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+		v.add(CodeType, node, path, "deve ser true ou false")
+	}
+}
+
+// duration confere uma duração positiva no formato do Go, como 30m ou 1h30m
+func (v *validator) duration(node *yaml.Node, path string) {
+	if node.Kind != yaml.ScalarNode {
+		v.add(CodeDuration, node, path, "deve ser uma duração como 30m ou 1h30m")
+		return
+	}
+	d, err := time.ParseDuration(node.Value)
+	if err != nil || d <= 0 {
+		v.add(CodeDuration, node, path, "duração inválida %q: use um valor como 30m ou 1h30m", node.Value)
+	}
+}
+
+// lab confere a raiz do documento lab.yaml
+func (v *validator) lab(node *yaml.Node) {
+	v.mapping(node, "", map[string]fieldCheck{
+		"schemaVersion": func(n *yaml.Node, path string) {
+			if n.Value != Version {
+				v.add(CodeVersion, n, path, "versão do schema não suportada %q: use %s", n.Value, Version)
+			}
+		},
+		"name":         v.text,
+		"title":        v.text,
+		"description":  v.text,
+		"duration":     v.duration,
+		"maxDuration":  v.duration,
+		"timerEnabled": v.boolean,
+		"image":        v.text,
+		"privileged":   v.boolean,
+		"type":         v.text,
+		"entrypoint":   v.text,
+		"youtubeVideo": v.str,
+		"tasks": func(n *yaml.Node, path string) {
+			if empty(n) {
+				v.add(CodeEmpty, n, path, "o laboratório precisa de ao menos uma tarefa")
+				return
+			}
+			v.sequence(n, path, v.task)
+		},
+	}, "name", "title", "description", "duration", "image", "tasks")
+}
+
+// task confere uma tarefa
+func (v *validator) task(node *yaml.Node, path string) {
+	v.mapping(node, path, map[string]fieldCheck{
+		"name":        v.text,
+		"description": v.text,
+		"steps": func(n *yaml.Node, path string) {
+			if empty(n) {
+				v.add(CodeEmpty, n, path, "a tarefa precisa de ao menos um passo")
+				return
+			}
+			v.sequence(n, path, v.step)
+		},
+		"tips": func(n *yaml.Node, path string) {
+			v.sequence(n, path, v.tip)
+		},
+		"validation": func(n *yaml.Node, path string) {
+			if empty(n) {
+				v.add(CodeEmpty, n, path, "validation está vazia: remova o campo ou adicione ao menos uma verificação")
+				return
+			}
+			v.sequence(n, path, v.validation)
+		},
+		"solution": v.text,
+	}, "name", "description", "steps")
+}
+
+// step confere um passo, em texto ou na forma detalhada
+func (v *validator) step(node *yaml.Node, path string) {
+	if node.Kind == yaml.ScalarNode {
+		v.text(node, path)
+		return
+	}
+	v.mapping(node, path, map[string]fieldCheck{
+		"description":    v.text,
+		"command":        v.text,
+		"expectedOutput": func(*yaml.Node, string) {},
+		"hint":           v.text,
+	}, "description")
+}
+
+// tip confere uma dica
+func (v *validator) tip(node *yaml.Node, path string) {
+	v.mapping(node, path, map[string]fieldCheck{
+		"type": func(n *yaml.Node, path string) {
+			if !slices.Contains(TipTypes, n.Value) {
+				v.add(CodeTipType, n, path, "tipo de dica desconhecido %q: use %s", n.Value, strings.Join(TipTypes, ", "))
+			}
+		},
+		"title":   v.text,
+		"content": v.text,
+	}, "type", "title", "content")
+}
+
+// validation confere uma verificação da tarefa
+func (v *validator) validation(node *yaml.Node, path string) {
+	v.mapping(node, path, map[string]fieldCheck{
+		"command":            v.text,
+		"expectedOutput":     func(*yaml.Node, string) {},
+		"expectedExpression": v.text,
+		"errorMessage":       v.text,
+	}, "command")
+	if node.Kind == yaml.MappingNode && value(node, "expectedOutput") == nil && value(node, "expectedExpression") == nil {
+		v.add(CodeRequired, node, path, "a verificação precisa de expectedOutput ou expectedExpression")
+	}
+}
+
+// join monta o caminho de um campo, como tasks[0].tips[1].type
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// empty informa se a lista está vazia ou sem valor
+func empty(node *yaml.Node) bool {
+	return node.Tag == "!!null" || node.Kind == yaml.SequenceNode && len(node.Content) == 0
+}
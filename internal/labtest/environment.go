@@ -0,0 +1,222 @@
+package labtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/k8s"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+)
+
+// Shell é o interpretador dos scripts de solução e das validações, o mesmo
+// do terminal do aluno
+const Shell = "bash"
+
+// Output é o resultado de um script executado no ambiente
+type Output struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Environment é o ambiente isolado onde o laboratório é testado
+type Environment interface {
+	// Start cria o ambiente com a imagem do laboratório e aguarda que ele
+	// aceite comandos
+	Start(ctx context.Context) error
+	// Exec executa o script no ambiente. O erro indica falha ao executar o
+	// script, e não um código de saída diferente de zero.
+	Exec(ctx context.Context, script string) (Output, error)
+	// Stop remove o ambiente
+	Stop(ctx context.Context) error
+	String() string
+}
+
+// Spec descreve o contêiner do laboratório
+type Spec struct {
+	Name       string
+	Image      string
+	Entrypoint string
+	Privileged bool
+}
+
+// invalidNameChars são os caracteres que não podem aparecer no nome do pod
+// ou do contêiner, como os acentos dos nomes dos laboratórios
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// NewSpec monta o contêiner a partir do laboratório, com um nome único que
+// segue o formato dos nomes do Kubernetes (RFC 1123)
+func NewSpec(lab *labschema.Lab) Spec {
+	name := "girus-test-" + invalidNameChars.ReplaceAllString(strings.ToLower(lab.Name), "-")
+	suffix := fmt.Sprintf("-%d", time.Now().Unix()%100000)
+	if len(name)+len(suffix) > 63 {
+		name = name[:63-len(suffix)]
+	}
+	return Spec{
+		Name:       strings.TrimRight(name, "-") + suffix,
+		Image:      lab.Image,
+		Entrypoint: lab.Entrypoint,
+		Privileged: lab.Privileged,
+	}
+}
+
+// run executa o comando e separa a saída padrão da saída de erro. Códigos de
+// saída diferentes de zero são retornados em Output.
+func run(cmd *exec.Cmd) (Output, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	output := Output{Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		output.ExitCode = exitErr.ExitCode()
+		return output, nil
+	}
+	return output, err
+}
+
+// commandError descreve a falha de um comando auxiliar com a saída de erro dele
+func commandError(action string, output Output, err error) error {
+	if err != nil {
+		return fmt.Errorf("%s: %w", action, err)
+	}
+	return fmt.Errorf("%s: código de saída %d: %s", action, output.ExitCode, strings.TrimSpace(output.Stderr))
+}
+
+// Pod testa o laboratório em um pod efêmero no cluster do contexto atual
+type Pod struct {
+	Spec
+	Namespace string
+	Timeout   time.Duration
+}
+
+func (p *Pod) String() string {
+	return fmt.Sprintf("pod %s/%s", p.Namespace, p.Name)
+}
+
+// kubectl monta um comando do kubectl no contexto do Girus, cancelável por ctx
+func kubectl(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "kubectl", append(k8s.KubectlArgs(), args...)...)
+}
+
+// manifest retorna o pod do laboratório. O tty mantém vivo o shell das
+// imagens que não têm um processo principal próprio.
+func (p *Pod) manifest() ([]byte, error) {
+	container := map[string]any{
+		"name":            "lab",
+		"image":           p.Image,
+		"imagePullPolicy": "IfNotPresent",
+		"stdin":           true,
+		"tty":             true,
+	}
+	if p.Entrypoint != "" {
+		container["command"] = []string{p.Entrypoint}
+	}
+	if p.Privileged {
+		container["securityContext"] = map[string]any{"privileged": true}
+	}
+	return json.Marshal(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":      p.Name,
+			"namespace": p.Namespace,
+			"labels":    map[string]string{"app": "girus-lab-test"},
+		},
+		"spec": map[string]any{
+			"restartPolicy":                 "Never",
+			"terminationGracePeriodSeconds": 0,
+			"containers":                    []any{container},
+		},
+	})
+}
+
+// Start cria o pod e aguarda que ele fique pronto
+func (p *Pod) Start(ctx context.Context) error {
+	manifest, err := p.manifest()
+	if err != nil {
+		return fmt.Errorf("falha ao gerar o pod de teste: %w", err)
+	}
+	create := kubectl(ctx, "create", "-f", "-")
+	create.Stdin = bytes.NewReader(manifest)
+	if output, err := run(create); err != nil || output.ExitCode != 0 {
+		return commandError("falha ao criar o pod de teste", output, err)
+	}
+
+	wait := kubectl(ctx, "wait", "--for=condition=Ready", "pod/"+p.Name, "-n", p.Namespace, fmt.Sprintf("--timeout=%s", p.Timeout))
+	if output, err := run(wait); err != nil || output.ExitCode != 0 {
+		return commandError("o pod de teste não ficou pronto", output, err)
+	}
+	return nil
+}
+
+// Exec executa o script no contêiner do pod
+func (p *Pod) Exec(ctx context.Context, script string) (Output, error) {
+	return run(kubectl(ctx, "exec", "-n", p.Namespace, p.Name, "-c", "lab", "--", Shell, "-c", script))
+}
+
+// Stop remove o pod sem aguardar o término
+func (p *Pod) Stop(ctx context.Context) error {
+	cmd := kubectl(ctx, "delete", "pod", p.Name, "-n", p.Namespace, "--wait=false", "--ignore-not-found")
+	if output, err := run(cmd); err != nil || output.ExitCode != 0 {
+		return commandError("falha ao remover o pod de teste", output, err)
+	}
+	return nil
+}
+
+// Container testa o laboratório em um contêiner local do Docker ou do Podman
+type Container struct {
+	Spec
+	// Engine é o executável do engine de contêineres (docker ou podman)
+	Engine string
+}
+
+func (c *Container) String() string {
+	return fmt.Sprintf("contêiner %s (%s)", c.Name, c.Engine)
+}
+
+// Start inicia o contêiner e confere que ele continua em execução
+func (c *Container) Start(ctx context.Context) error {
+	args := []string{"run", "-d", "-i", "-t", "--name", c.Name, "--label", "app=girus-lab-test"}
+	if c.Privileged {
+		args = append(args, "--privileged")
+	}
+	if c.Entrypoint != "" {
+		args = append(args, "--entrypoint", c.Entrypoint)
+	}
+	args = append(args, c.Image)
+	if output, err := run(exec.CommandContext(ctx, c.Engine, args...)); err != nil || output.ExitCode != 0 {
+		return commandError("falha ao iniciar o contêiner de teste", output, err)
+	}
+
+	output, err := run(exec.CommandContext(ctx, c.Engine, "inspect", "-f", "{{.State.Running}}", c.Name))
+	if err != nil || output.ExitCode != 0 {
+		return commandError("falha ao inspecionar o contêiner de teste", output, err)
+	}
+	if strings.TrimSpace(output.Stdout) != "true" {
+		logs, _ := run(exec.CommandContext(ctx, c.Engine, "logs", "--tail", "20", c.Name))
+		return fmt.Errorf("o contêiner de teste terminou logo após iniciar: %s", strings.TrimSpace(logs.Stdout+logs.Stderr))
+	}
+	return nil
+}
+
+// Exec executa o script no contêiner
+func (c *Container) Exec(ctx context.Context, script string) (Output, error) {
+	return run(exec.CommandContext(ctx, c.Engine, "exec", c.Name, Shell, "-c", script))
+}
+
+// Stop remove o contêiner
+func (c *Container) Stop(ctx context.Context) error {
+	if output, err := run(exec.CommandContext(ctx, c.Engine, "rm", "-f", c.Name)); err != nil || output.ExitCode != 0 {
+		return commandError("falha ao remover o contêiner de teste", output, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package labtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Estrutura do relatório JUnit XML, lido pelas ferramentas de CI (GitHub
+// Actions, GitLab, Jenkins). Cada tarefa do laboratório é um caso de teste.
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// seconds formata a duração no formato do atributo time
+func seconds(value float64) string {
+	return fmt.Sprintf("%.3f", value)
+}
+
+// WriteJUnit escreve os relatórios no formato JUnit XML, com uma suíte por
+// laboratório e um caso de teste por tarefa
+func WriteJUnit(w io.Writer, reports []*Report) error {
+	suites := junitSuites{}
+	total := 0.0
+	for _, report := range reports {
+		suite := junitSuite{Name: report.Lab, Time: seconds(report.Seconds)}
+		if report.Error != "" {
+			// O erro que impediu as tarefas de rodar vira um caso de teste com falha
+			suite.Cases = append(suite.Cases, junitCase{
+				Name:      "00. ambiente",
+				ClassName: report.Lab,
+				Time:      seconds(report.Seconds),
+				Failure:   &junitMessage{Message: report.Error},
+			})
+			suite.Tests++
+			suite.Failures++
+		}
+		for i, task := range report.Tasks {
+			testCase := junitCase{
+				Name:      fmt.Sprintf("%02d. %s", i+1, task.Name),
+				ClassName: report.Lab,
+				Time:      seconds(task.Seconds),
+			}
+			var details []string
+			for _, validation := range task.Validations {
+				line := fmt.Sprintf("[%s] %s", validation.Status, validation.Command)
+				if validation.Message != "" {
+					line += ": " + validation.Message
+				}
+				details = append(details, line)
+			}
+			switch task.Status {
+			case StatusFailed:
+				message := task.Message
+				if message == "" {
+					message = "validação falhou"
+				}
+				testCase.Failure = &junitMessage{Message: message, Text: strings.Join(details, "\n")}
+				suite.Failures++
+			case StatusSkipped:
+				testCase.Skipped = &junitMessage{Message: task.Message}
+				suite.Skipped++
+			default:
+				testCase.SystemOut = strings.Join(details, "\n")
+			}
+			suite.Cases = append(suite.Cases, testCase)
+			suite.Tests++
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		total += report.Seconds
+		suites.Suites = append(suites.Suites, suite)
+	}
+	suites.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return fmt.Errorf("falha ao gerar o relatório JUnit: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,237 @@
+// Package labtest executa um laboratório sem o aluno: inicia a imagem do
+// laboratório em um ambiente isolado, roda o script de solução de cada
+// tarefa e confere as validações, como o backend faria ao final da tarefa
+package labtest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/badtuxx/girus-cli/internal/labschema"
+)
+
+// Status é o resultado de uma tarefa ou validação
+type Status string
+
+// Resultados possíveis. Tarefas sem validation são apenas executadas.
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Report é o resultado do teste de um laboratório
+type Report struct {
+	Lab         string       `json:"lab"`
+	Title       string       `json:"title"`
+	Environment string       `json:"environment"`
+	Tasks       []TaskResult `json:"tasks"`
+	// Seconds é a duração do teste, em segundos
+	Seconds float64 `json:"seconds"`
+	// Error é o erro que impediu as tarefas de rodar, como uma falha ao
+	// carregar o arquivo ou ao iniciar o ambiente
+	Error string `json:"error,omitempty"`
+}
+
+// Failed cria o relatório de um laboratório cujas tarefas não puderam rodar
+func Failed(lab, title, environment string, err error) *Report {
+	return &Report{Lab: lab, Title: title, Environment: environment, Tasks: []TaskResult{}, Error: err.Error()}
+}
+
+// Passed informa se o teste rodou e todas as tarefas passaram
+func (r *Report) Passed() bool {
+	if r.Error != "" {
+		return false
+	}
+	for _, task := range r.Tasks {
+		if task.Status == StatusFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// TaskResult é o resultado de uma tarefa
+type TaskResult struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	// Solution informa se a tarefa tem um script de solução
+	Solution    bool               `json:"solution"`
+	Message     string             `json:"message,omitempty"`
+	Validations []ValidationResult `json:"validations,omitempty"`
+	Seconds     float64            `json:"seconds"`
+}
+
+// ValidationResult é o resultado de uma validação da tarefa
+type ValidationResult struct {
+	Command  string `json:"command"`
+	Expected string `json:"expected"`
+	Output   string `json:"output"`
+	Status   Status `json:"status"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Options controlam a execução dos testes
+type Options struct {
+	// Timeout limita cada script de solução e cada validação
+	Timeout time.Duration
+	// OnTask é chamada ao final de cada tarefa
+	OnTask func(TaskResult)
+}
+
+// Run executa as tarefas do laboratório em ordem no ambiente já iniciado:
+// primeiro o script de solução da tarefa, depois as validações dela
+func Run(ctx context.Context, env Environment, lab *labschema.Lab, options Options) *Report {
+	started := time.Now()
+	report := &Report{Lab: lab.Name, Title: lab.Title, Environment: env.String()}
+	for _, task := range lab.Tasks {
+		result := runTask(ctx, env, task, options.Timeout)
+		report.Tasks = append(report.Tasks, result)
+		if options.OnTask != nil {
+			options.OnTask(result)
+		}
+	}
+	report.Seconds = time.Since(started).Seconds()
+	return report
+}
+
+// runTask executa a solução e as validações de uma tarefa
+func runTask(ctx context.Context, env Environment, task labschema.Task, timeout time.Duration) (result TaskResult) {
+	started := time.Now()
+	result = TaskResult{Name: task.Name, Solution: strings.TrimSpace(task.Solution) != ""}
+	defer func() { result.Seconds = time.Since(started).Seconds() }()
+
+	if result.Solution {
+		output, err := execute(ctx, env, task.Solution, timeout)
+		if err == nil && output.ExitCode != 0 {
+			err = fmt.Errorf("código de saída %d: %s", output.ExitCode, tail(output.Stdout+output.Stderr))
+		}
+		if err != nil {
+			result.Status = StatusFailed
+			result.Message = fmt.Sprintf("o script de solução falhou: %v", err)
+			return result
+		}
+	}
+
+	if len(task.Validation) == 0 {
+		result.Status = StatusSkipped
+		result.Message = "a tarefa não tem validation"
+		return result
+	}
+
+	result.Status = StatusPassed
+	for _, validation := range task.Validation {
+		check := ValidationResult{Command: validation.Command, Expected: expected(validation)}
+		output, err := execute(ctx, env, validation.Command, timeout)
+		check.Output = strings.TrimSpace(output.Stdout)
+		if err == nil {
+			err = Match(validation, output.Stdout)
+		}
+		if err != nil {
+			check.Status = StatusFailed
+			check.Message = err.Error()
+			result.Status = StatusFailed
+		} else {
+			check.Status = StatusPassed
+		}
+		result.Validations = append(result.Validations, check)
+	}
+	if result.Status == StatusFailed && !result.Solution {
+		result.Message = "a tarefa não tem script de solução"
+	}
+	return result
+}
+
+// execute roda o script no ambiente com o limite de tempo
+func execute(ctx context.Context, env Environment, script string, timeout time.Duration) (Output, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	output, err := env.Exec(ctx, script)
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("tempo esgotado após %s", timeout)
+	}
+	return output, err
+}
+
+// expected descreve o resultado esperado da validação
+func expected(v labschema.Validation) string {
+	if v.ExpectedOutput != "" {
+		return v.ExpectedOutput
+	}
+	return v.ExpectedExpression
+}
+
+// Match compara a saída do comando com o resultado esperado da validação.
+// expectedOutput deve ser igual à saída, sem os espaços das pontas;
+// expectedExpression aceita "~ texto", que procura o texto na saída, e
+// comparações numéricas como ">= 2", "> 0" ou "== 3".
+func Match(v labschema.Validation, output string) error {
+	output = strings.TrimSpace(output)
+	if v.ExpectedOutput != "" {
+		if output != strings.TrimSpace(v.ExpectedOutput) {
+			return fmt.Errorf("saída %q, esperado %q", tail(output), v.ExpectedOutput)
+		}
+		return nil
+	}
+
+	expression := strings.TrimSpace(v.ExpectedExpression)
+	if text, found := strings.CutPrefix(expression, "~"); found {
+		if !strings.Contains(output, strings.TrimSpace(text)) {
+			return fmt.Errorf("saída %q não contém %q", tail(output), strings.TrimSpace(text))
+		}
+		return nil
+	}
+
+	for _, operator := range []string{">=", "<=", "==", "!=", ">", "<"} {
+		operand, found := strings.CutPrefix(expression, operator)
+		if !found {
+			continue
+		}
+		want, err := strconv.ParseFloat(strings.TrimSpace(operand), 64)
+		if err != nil {
+			return fmt.Errorf("expressão inválida %q: %w", expression, err)
+		}
+		got, err := strconv.ParseFloat(output, 64)
+		if err != nil {
+			return fmt.Errorf("saída %q não é um número, esperado %s", tail(output), expression)
+		}
+		if !compare(got, operator, want) {
+			return fmt.Errorf("saída %s, esperado %s", output, expression)
+		}
+		return nil
+	}
+	return fmt.Errorf("expressão não suportada %q: use ~ texto ou uma comparação como >= 2", expression)
+}
+
+// compare aplica o operador aos números
+func compare(got float64, operator string, want float64) bool {
+	switch operator {
+	case ">=":
+		return got >= want
+	case "<=":
+		return got <= want
+	case "==":
+		return got == want
+	case "!=":
+		return got != want
+	case ">":
+		return got > want
+	}
+	return got < want
+}
+
+// tail encurta saídas longas para as mensagens, mantendo o final
+func tail(text string) string {
+	text = strings.TrimSpace(text)
+	const limit = 300
+	if runes := []rune(text); len(runes) > limit {
+		return "..." + string(runes[len(runes)-limit:])
+	}
+	return text
+}
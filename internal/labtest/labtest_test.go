@@ -0,0 +1,139 @@
+package labtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/badtuxx/girus-cli/internal/labschema"
+)
+
+// shellEnvironment executa os scripts no bash da máquina, em um diretório
+// temporário, no lugar do contêiner do laboratório
+type shellEnvironment struct {
+	dir string
+}
+
+func (e *shellEnvironment) Start(ctx context.Context) error { return nil }
+func (e *shellEnvironment) Stop(ctx context.Context) error  { return nil }
+func (e *shellEnvironment) String() string                  { return "shell local" }
+
+func (e *shellEnvironment) Exec(ctx context.Context, script string) (Output, error) {
+	cmd := exec.CommandContext(ctx, Shell, "-c", script)
+	cmd.Dir = e.dir
+	return run(cmd)
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		validation labschema.Validation
+		output     string
+		ok         bool
+	}{
+		{labschema.Validation{ExpectedOutput: "ok"}, "ok\n", true},
+		{labschema.Validation{ExpectedOutput: "ok"}, "not ok", false},
+		{labschema.Validation{ExpectedExpression: "~ deployment"}, "deployments  deploy  apps/v1", true},
+		{labschema.Validation{ExpectedExpression: "~ cronjobs"}, "jobs", false},
+		{labschema.Validation{ExpectedExpression: ">= 2"}, "2", true},
+		{labschema.Validation{ExpectedExpression: "> 3"}, "3", false},
+		{labschema.Validation{ExpectedExpression: "> 0"}, "sem número", false},
+		{labschema.Validation{ExpectedExpression: "entre 1 e 2"}, "1", false},
+	}
+	for _, test := range tests {
+		err := Match(test.validation, test.output)
+		if (err == nil) != test.ok {
+			t.Errorf("Match(%+v, %q) = %v, esperado ok=%v", test.validation, test.output, err, test.ok)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	lab := &labschema.Lab{
+		Name: "exemplo",
+		Tasks: []labschema.Task{
+			{
+				Name:       "Com solução",
+				Solution:   "echo linux > arquivo.txt",
+				Validation: []labschema.Validation{{Command: "cat arquivo.txt", ExpectedOutput: "linux"}},
+			},
+			{
+				Name:       "Sem solução",
+				Validation: []labschema.Validation{{Command: "test -f outro.txt && echo ok", ExpectedOutput: "ok"}},
+			},
+			{
+				Name:     "Solução com erro",
+				Solution: "exit 3",
+			},
+			{
+				Name: "Sem validation",
+			},
+		},
+	}
+	report := Run(context.Background(), &shellEnvironment{dir: t.TempDir()}, lab, Options{})
+
+	want := []Status{StatusPassed, StatusFailed, StatusFailed, StatusSkipped}
+	for i, task := range report.Tasks {
+		if task.Status != want[i] {
+			t.Errorf("tarefa %q: status %s, esperado %s (%s)", task.Name, task.Status, want[i], task.Message)
+		}
+	}
+	if !strings.Contains(report.Tasks[2].Message, "código de saída 3") {
+		t.Errorf("mensagem da solução com erro: %q", report.Tasks[2].Message)
+	}
+	if report.Passed() {
+		t.Error("o relatório não deveria passar com tarefas falhando")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, []*Report{report}); err != nil {
+		t.Fatalf("WriteJUnit retornou erro: %v", err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("JUnit inválido: %v\n%s", err, buf.String())
+	}
+	if suites.Tests != 4 || suites.Failures != 2 || suites.Skipped != 1 || len(suites.Suites[0].Cases) != 4 {
+		t.Errorf("contagens do JUnit inesperadas:\n%s", buf.String())
+	}
+}
+
+func TestFailedReport(t *testing.T) {
+	report := Failed("exemplo", "Exemplo", "pod default/girus-test-exemplo", errors.New("timeout ao aguardar o pod"))
+	if report.Passed() {
+		t.Error("um laboratório cujo ambiente não iniciou não deveria passar")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, []*Report{report}); err != nil {
+		t.Fatalf("WriteJUnit retornou erro: %v", err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("JUnit inválido: %v\n%s", err, buf.String())
+	}
+	if suites.Tests != 1 || suites.Failures != 1 || !strings.Contains(buf.String(), "timeout ao aguardar o pod") {
+		t.Errorf("a falha do ambiente deveria virar um caso de teste com falha:\n%s", buf.String())
+	}
+}
+
+func TestNewSpec(t *testing.T) {
+	name := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	for _, lab := range []string{
+		"linux-administración-usuarios-es",
+		"Docker_Volumes",
+		"kubernetes-" + strings.Repeat("x", 80),
+	} {
+		spec := NewSpec(&labschema.Lab{Name: lab})
+		if len(spec.Name) > 63 || !name.MatchString(spec.Name) {
+			t.Errorf("NewSpec(%q).Name = %q, esperado um nome RFC 1123 com até 63 caracteres", lab, spec.Name)
+		}
+	}
+	if spec := NewSpec(&labschema.Lab{Name: "linux-administración-usuarios-es"}); !strings.HasPrefix(spec.Name, "girus-test-linux-administraci-n-usuarios-es-") {
+		t.Errorf("nome inesperado: %q", spec.Name)
+	}
+}
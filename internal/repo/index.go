@@ -13,28 +13,28 @@ import (
 
 // Repository representa um repositório de laboratórios
 type Repository struct {
-	Name        string `yaml:"name"`
-	URL         string `yaml:"url"`
-	Description string `yaml:"description"`
-	Version     string `yaml:"version"`
+	Name        string `yaml:"name" json:"name"`
+	URL         string `yaml:"url" json:"url"`
+	Description string `yaml:"description" json:"description"`
+	Version     string `yaml:"version" json:"version"`
 }
 
 // Index representa o arquivo de índice de um repositório
 type Index struct {
-	APIVersion string     `yaml:"apiVersion"`
-	Generated  string     `yaml:"generated"`
-	Labs       []LabEntry `yaml:"labs"`
+	APIVersion string     `yaml:"apiVersion" json:"apiVersion"`
+	Generated  string     `yaml:"generated" json:"generated"`
+	Labs       []LabEntry `yaml:"labs" json:"labs"`
 }
 
 // LabEntry representa um laboratório no índice
 type LabEntry struct {
-	ID          string   `yaml:"id"`
-	Title       string   `yaml:"title"`
-	Description string   `yaml:"description"`
-	Version     string   `yaml:"version"`
-	Duration    string   `yaml:"duration"`
-	Tags        []string `yaml:"tags"`
-	URL         string   `yaml:"url"`
+	ID          string   `yaml:"id" json:"id"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description" json:"description"`
+	Version     string   `yaml:"version" json:"version"`
+	Duration    string   `yaml:"duration" json:"duration"`
+	Tags        []string `yaml:"tags" json:"tags"`
+	URL         string   `yaml:"url" json:"url"`
 }
 
 // RepositoryManager gerencia os repositórios de laboratórios
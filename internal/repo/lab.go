@@ -2,12 +2,15 @@ package repo
 
 import (
 	"fmt"
-	"gopkg.in/yaml.v3"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/badtuxx/girus-cli/internal/common"
+	"github.com/badtuxx/girus-cli/internal/labschema"
+	"gopkg.in/yaml.v3"
 )
 
 // LabManager gerencia os laboratórios
@@ -88,15 +91,24 @@ func (lm *LabManager) DownloadLab(repoName, labName, version string) error {
 		return fmt.Errorf("erro ao baixar laboratório (status: %d)", resp.StatusCode)
 	}
 
-	// Salva o arquivo
-	labFile := filepath.Join(labPath, "lab.yaml")
-	out, err := os.Create(labFile)
+	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("erro ao criar arquivo do laboratório: %v", err)
+		return fmt.Errorf("erro ao baixar laboratório: %v", err)
+	}
+
+	// Laboratórios publicados como lab.yaml puro são guardados já empacotados
+	// no ConfigMap, prontos para o kubectl apply
+	manifest, _, err := labschema.Manifest(data, labschema.Provenance{
+		Source:      lab.URL,
+		GeneratedBy: "girus-cli " + common.ShortVersion(),
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao validar laboratório: %w", err)
 	}
-	defer out.Close()
 
-	if _, err := io.Copy(out, resp.Body); err != nil {
+	// Salva o arquivo
+	labFile := filepath.Join(labPath, "lab.yaml")
+	if err := os.WriteFile(labFile, manifest, 0644); err != nil {
 		return fmt.Errorf("erro ao salvar laboratório: %v", err)
 	}
 
@@ -107,13 +119,14 @@ func (lm *LabManager) DownloadLab(repoName, labName, version string) error {
 func (lm *LabManager) getIndex(repo Repository) (*Index, error) {
 	// Tenta obter do cache primeiro
 	cacheFile := filepath.Join(lm.cachePath, repo.Name, "index.yaml")
-	fmt.Printf("Verificando cache em: %s\n", cacheFile)
+	// As mensagens de progresso vão para o stderr, mantendo o stdout limpo para -o json|yaml
+	fmt.Fprintf(os.Stderr, "Verificando cache em: %s\n", cacheFile)
 
 	// Verifica se o arquivo de cache existe e não está expirado
 	if info, err := os.Stat(cacheFile); err == nil {
 		// Verifica se o arquivo tem menos de 7 dias
 		if time.Since(info.ModTime()) < 7*24*time.Hour {
-			fmt.Println("Usando índice do cache")
+			fmt.Fprintln(os.Stderr, "Usando índice do cache")
 			data, err := os.ReadFile(cacheFile)
 			if err == nil {
 				var index Index
@@ -122,13 +135,13 @@ func (lm *LabManager) getIndex(repo Repository) (*Index, error) {
 				}
 			}
 		} else {
-			fmt.Println("Cache expirado, baixando novo índice")
+			fmt.Fprintln(os.Stderr, "Cache expirado, baixando novo índice")
 		}
 	}
 
 	// Se não estiver em cache ou estiver expirado, baixa do repositório
 	indexURL := fmt.Sprintf("%s/index.yaml", repo.URL)
-	fmt.Printf("Buscando índice em: %s\n", indexURL)
+	fmt.Fprintf(os.Stderr, "Buscando índice em: %s\n", indexURL)
 	resp, err := http.Get(indexURL)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao acessar índice do repositório: %v", err)
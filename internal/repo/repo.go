@@ -14,18 +14,18 @@ import (
 
 // Estrutura para o arquivo index.yaml
 type IndexFile struct {
-	Labs []Lab `yaml:"labs"`
+	Labs []Lab `yaml:"labs" json:"labs"`
 }
 
 // Estrutura para cada laboratório no index.yaml
 type Lab struct {
-	ID          string   `yaml:"id"`
-	Title       string   `yaml:"title"`
-	Description string   `yaml:"description"`
-	Version     string   `yaml:"version"`
-	Duration    string   `yaml:"duration"`
-	Tags        []string `yaml:"tags,omitempty"`
-	URL         string   `yaml:"url"`
+	ID          string   `yaml:"id" json:"id"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description" json:"description"`
+	Version     string   `yaml:"version" json:"version"`
+	Duration    string   `yaml:"duration" json:"duration"`
+	Tags        []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	URL         string   `yaml:"url" json:"url"`
 }
 
 // URL padrão do index.yaml
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// JSON escreve cada evento como um objeto JSON por linha
+type JSON struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	now     func() time.Time
+}
+
+// NewJSON cria um Reporter de eventos JSON
+func NewJSON(w io.Writer) *JSON {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &JSON{encoder: encoder, now: time.Now}
+}
+
+// Start emite o evento de início da etapa
+func (j *JSON) Start(step string) {
+	j.emit(Event{Type: EventStart, Step: step})
+}
+
+// Progress emite o andamento da etapa
+func (j *JSON) Progress(step, message string, current, total int) {
+	j.emit(Event{Type: EventProgress, Step: step, Message: message, Current: current, Total: total})
+}
+
+// Done emite a conclusão da etapa
+func (j *JSON) Done(step, message string) {
+	j.emit(Event{Type: EventDone, Step: step, Message: message})
+}
+
+// Fail emite a falha da etapa
+func (j *JSON) Fail(step string, err error) {
+	event := Event{Type: EventFail, Step: step}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	j.emit(event)
+}
+
+// emit grava o evento com o horário atual
+func (j *JSON) emit(event Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	event.Time = j.now().UTC()
+	_ = j.encoder.Encode(event)
+}
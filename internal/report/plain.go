@@ -0,0 +1,70 @@
+package report
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// Plain escreve uma linha por evento, adequada para logs de CI. Eventos de
+// progresso repetidos (mesma mensagem e contagem) são omitidos.
+type Plain struct {
+	mu   sync.Mutex
+	w    io.Writer
+	last map[string]string
+}
+
+// NewPlain cria um Reporter de linhas de log
+func NewPlain(w io.Writer) *Plain {
+	return &Plain{w: w, last: map[string]string{}}
+}
+
+// Start registra o início da etapa
+func (p *Plain) Start(step string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.last, step)
+	writeLine(p.w, "• %s...", step)
+}
+
+// Progress registra o andamento da etapa
+func (p *Plain) Progress(step, message string, current, total int) {
+	line := strings.TrimSpace(strings.Join([]string{message, counter(current, total)}, " "))
+	if line == "" {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.last[step] == line {
+		return
+	}
+	p.last[step] = line
+	writeLine(p.w, "  %s: %s", step, line)
+}
+
+// Done registra a conclusão da etapa
+func (p *Plain) Done(step, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.last, step)
+	if message != "" {
+		writeLine(p.w, "%s %s: %s", color.GreenString("✓"), step, message)
+		return
+	}
+	writeLine(p.w, "%s %s", color.GreenString("✓"), step)
+}
+
+// Fail registra a falha da etapa
+func (p *Plain) Fail(step string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.last, step)
+	if err != nil {
+		writeLine(p.w, "%s %s: %v", color.RedString("✗"), step, err)
+		return
+	}
+	writeLine(p.w, "%s %s", color.RedString("✗"), step)
+}
@@ -0,0 +1,112 @@
+// Package report exibe o progresso das etapas longas da CLI (criação do
+// cluster, implantação dos manifestos, instalação de laboratórios) como
+// eventos, renderizados como spinner no terminal, linhas de log no CI ou JSON.
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Modos aceitos pela flag global --progress
+const (
+	ModeAuto  = "auto"
+	ModeTTY   = "tty"
+	ModePlain = "plain"
+	ModeJSON  = "json"
+)
+
+// Modes lista os modos de exibição do progresso
+var Modes = []string{ModeAuto, ModeTTY, ModePlain, ModeJSON}
+
+// Tipos de evento emitidos para cada etapa
+const (
+	EventStart    = "start"
+	EventProgress = "progress"
+	EventDone     = "done"
+	EventFail     = "fail"
+)
+
+// Event é um evento de progresso de uma etapa. Current e Total são opcionais
+// e indicam quantos itens da etapa já foram concluídos (objetos aplicados,
+// pods prontos).
+type Event struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Step    string    `json:"step"`
+	Message string    `json:"message,omitempty"`
+	Current int       `json:"current,omitempty"`
+	Total   int       `json:"total,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Reporter recebe os eventos das etapas. Uma etapa começa com Start, pode
+// emitir vários Progress e termina com Done ou Fail.
+type Reporter interface {
+	Start(step string)
+	Progress(step, message string, current, total int)
+	Done(step, message string)
+	Fail(step string, err error)
+}
+
+// ValidMode informa se o modo de exibição é conhecido
+func ValidMode(mode string) bool {
+	for _, known := range Modes {
+		if mode == known {
+			return true
+		}
+	}
+	return false
+}
+
+// New cria o Reporter do modo informado. No modo auto, o spinner é usado
+// quando o stdout é um terminal e as linhas de log nos demais casos. Os
+// eventos JSON vão para o stderr, um por linha, para não se misturarem às
+// mensagens dos comandos.
+func New(mode string) Reporter {
+	if mode == ModeAuto || mode == "" {
+		mode = ModePlain
+		if term.IsTerminal(int(os.Stdout.Fd())) {
+			mode = ModeTTY
+		}
+	}
+
+	switch mode {
+	case ModeTTY:
+		return NewSpinner(os.Stdout)
+	case ModeJSON:
+		return NewJSON(os.Stderr)
+	default:
+		return NewPlain(os.Stdout)
+	}
+}
+
+// Run executa fn como uma etapa, emitindo Start e Done ou Fail conforme o
+// resultado, que é retornado
+func Run(r Reporter, step string, fn func() error) error {
+	r.Start(step)
+	if err := fn(); err != nil {
+		r.Fail(step, err)
+		return err
+	}
+	r.Done(step, "")
+	return nil
+}
+
+// counter formata o andamento de uma etapa no formato (atual/total)
+func counter(current, total int) string {
+	if total <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%d/%d)", current, total)
+}
+
+// writeLine escreve uma linha ignorando erros de escrita, que não devem
+// interromper a operação acompanhada
+func writeLine(w io.Writer, format string, args ...any) {
+	_, _ = fmt.Fprintf(w, format+"\n", args...)
+}
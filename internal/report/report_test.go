@@ -0,0 +1,75 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+func TestPlain(t *testing.T) {
+	color.NoColor = true
+
+	var out bytes.Buffer
+	r := NewPlain(&out)
+	r.Start("Implantando Girus")
+	r.Progress("Implantando Girus", "ConfigMap/girus-config", 1, 2)
+	// Eventos repetidos, como os das verificações periódicas, não geram novas linhas
+	r.Progress("Implantando Girus", "ConfigMap/girus-config", 1, 2)
+	r.Progress("Implantando Girus", "Deployment/girus-backend", 2, 2)
+	r.Done("Implantando Girus", "2 objetos aplicados")
+	r.Fail("Aguardando os pods", errors.New("timeout"))
+
+	want := `• Implantando Girus...
+  Implantando Girus: ConfigMap/girus-config (1/2)
+  Implantando Girus: Deployment/girus-backend (2/2)
+✓ Implantando Girus: 2 objetos aplicados
+✗ Aguardando os pods: timeout
+`
+	if out.String() != want {
+		t.Errorf("saída inesperada:\n%s\nesperado:\n%s", out.String(), want)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var out bytes.Buffer
+	r := NewJSON(&out)
+	r.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	if err := Run(r, "Criando cluster", func() error { return errors.New("sem docker") }); err == nil {
+		t.Fatal("Run deveria retornar o erro da etapa")
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("esperados 2 eventos, obtidos %d: %s", len(lines), out.String())
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(lines[1]), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != EventFail || event.Step != "Criando cluster" || event.Error != "sem docker" {
+		t.Errorf("evento inesperado: %+v", event)
+	}
+	if !strings.Contains(lines[0], `"time":"2025-01-02T03:04:05Z","type":"start"`) {
+		t.Errorf("evento de início inesperado: %s", lines[0])
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	color.NoColor = true
+
+	var out bytes.Buffer
+	r := NewSpinner(&out)
+	r.Start("Reiniciando backend")
+	r.Progress("Reiniciando backend", "1 de 1 réplicas atualizadas", 0, 0)
+	r.Done("Reiniciando backend", "")
+
+	if !strings.HasSuffix(out.String(), "\r\033[K✓ Reiniciando backend\n") {
+		t.Errorf("a etapa concluída deveria terminar a linha: %q", out.String())
+	}
+}
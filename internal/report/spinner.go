@@ -0,0 +1,142 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// spinnerFrames são os quadros da animação da etapa em andamento
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval é o intervalo entre dois quadros do spinner
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner mostra a etapa em andamento em uma única linha animada do
+// terminal, com a última mensagem de progresso, e deixa uma linha com o
+// resultado de cada etapa concluída
+type Spinner struct {
+	mu      sync.Mutex
+	w       io.Writer
+	step    string
+	message string
+	current int
+	total   int
+	frame   int
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewSpinner cria um Reporter com spinner para terminais
+func NewSpinner(w io.Writer) *Spinner {
+	return &Spinner{w: w}
+}
+
+// Start inicia a animação da etapa, substituindo a etapa em andamento
+func (s *Spinner) Start(step string) {
+	s.halt()
+
+	s.mu.Lock()
+	s.step, s.message, s.current, s.total = step, "", 0, 0
+	s.stop = make(chan struct{})
+	s.stopped = make(chan struct{})
+	s.render()
+	s.mu.Unlock()
+
+	go s.animate(s.stop, s.stopped)
+}
+
+// Progress atualiza a mensagem e a contagem exibidas na linha da etapa
+func (s *Spinner) Progress(step, message string, current, total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if step != s.step {
+		return
+	}
+	s.message, s.current, s.total = message, current, total
+}
+
+// Done encerra a animação e deixa a etapa marcada como concluída
+func (s *Spinner) Done(step, message string) {
+	s.halt()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	line := color.GreenString("✓") + " " + step
+	if message != "" {
+		line += ": " + message
+	}
+	fmt.Fprintf(s.w, "\r\033[K%s\n", line)
+	s.step = ""
+}
+
+// Fail encerra a animação e deixa a etapa marcada como falha
+func (s *Spinner) Fail(step string, err error) {
+	s.halt()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	line := color.RedString("✗") + " " + step
+	if err != nil {
+		line += fmt.Sprintf(": %v", err)
+	}
+	fmt.Fprintf(s.w, "\r\033[K%s\n", line)
+	s.step = ""
+}
+
+// animate redesenha a linha da etapa até receber o sinal de parada
+func (s *Spinner) animate(stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.frame = (s.frame + 1) % len(spinnerFrames)
+			s.render()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// halt para a animação em andamento, se houver
+func (s *Spinner) halt() {
+	s.mu.Lock()
+	stop, stopped := s.stop, s.stopped
+	s.stop, s.stopped = nil, nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-stopped
+	}
+}
+
+// render desenha a linha da etapa, cortada na largura do terminal para que o
+// retorno de carro sempre volte ao início da mesma linha
+func (s *Spinner) render() {
+	parts := []string{spinnerFrames[s.frame], s.step}
+	if count := counter(s.current, s.total); count != "" {
+		parts = append(parts, count)
+	}
+	if s.message != "" {
+		parts = append(parts, "· "+s.message)
+	}
+	line := []rune(strings.Join(parts, " "))
+
+	width := 80
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		width = w
+	}
+	if len(line) >= width {
+		line = append(line[:width-2], '…')
+	}
+	fmt.Fprintf(s.w, "\r\033[K%s", string(line))
+}
@@ -0,0 +1,42 @@
+package templates
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed components.yaml
+var componentsManifest []byte
+
+// Component é um componente da plataforma com a imagem fixada para esta versão do CLI
+type Component struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+}
+
+// Components retorna os componentes do manifesto de versões embutido
+func Components() ([]Component, error) {
+	var manifest struct {
+		Components []Component `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(componentsManifest, &manifest); err != nil {
+		return nil, fmt.Errorf("falha ao interpretar o manifesto de componentes: %w", err)
+	}
+	return manifest.Components, nil
+}
+
+// ComponentImage retorna a imagem fixada de um componente pelo nome do deployment
+func ComponentImage(name string) (string, error) {
+	components, err := Components()
+	if err != nil {
+		return "", err
+	}
+	for _, component := range components {
+		if component.Name == name {
+			return component.Image, nil
+		}
+	}
+	return "", fmt.Errorf("componente desconhecido: %s", name)
+}
@@ -1,13 +1,17 @@
 package templates_test
 
 import (
+	"bytes"
 	"embed"
+	"io"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"io/fs"
 
+	"github.com/badtuxx/girus-cli/internal/k8s"
 	"github.com/badtuxx/girus-cli/internal/templates"
 	"gopkg.in/yaml.v3"
 )
@@ -82,3 +86,58 @@ func TestListAndGetManifests(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultDeploymentUsesPinnedImages(t *testing.T) {
+	for _, path := range []string{"manifests/defaultDeployment.yaml", "manifests_es/defaultDeployment.yaml"} {
+		data, err := fs.ReadFile(templates.ManifestFS, path)
+		if err != nil {
+			t.Fatalf("erro ao ler %s: %v", path, err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		deployments := 0
+		for {
+			var doc struct {
+				Kind     string `yaml:"kind"`
+				Metadata struct {
+					Name string `yaml:"name"`
+				} `yaml:"metadata"`
+				Spec struct {
+					Template struct {
+						Spec struct {
+							Containers []struct {
+								Image string `yaml:"image"`
+							} `yaml:"containers"`
+						} `yaml:"spec"`
+					} `yaml:"template"`
+				} `yaml:"spec"`
+			}
+			if err := decoder.Decode(&doc); err != nil {
+				if err == io.EOF {
+					break
+				}
+				t.Fatalf("YAML inválido em %s: %v", path, err)
+			}
+			if doc.Kind != "Deployment" {
+				continue
+			}
+			deployments++
+
+			want, err := templates.ComponentImage(doc.Metadata.Name)
+			if err != nil {
+				t.Errorf("%s: %v", path, err)
+				continue
+			}
+			if tag := k8s.ImageTag(want); !strings.Contains(want, "@sha256:") && (tag == "" || tag == "latest") {
+				t.Errorf("%s: a imagem %s de %s não está fixada em uma tag versionada ou digest", path, want, doc.Metadata.Name)
+			}
+			if got := doc.Spec.Template.Spec.Containers[0].Image; got != want {
+				t.Errorf("%s: %s usa a imagem %s, mas o manifesto de componentes fixa %s", path, doc.Metadata.Name, got, want)
+			}
+		}
+
+		if deployments == 0 {
+			t.Errorf("nenhum deployment encontrado em %s", path)
+		}
+	}
+}